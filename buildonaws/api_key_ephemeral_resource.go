@@ -0,0 +1,248 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &apiKeyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &apiKeyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &apiKeyEphemeralResource{}
+)
+
+func NewAPIKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &apiKeyEphemeralResource{}
+}
+
+// apiKeyEphemeralResource creates API keys that live as long as the
+// Terraform operation using them, so they are never written to the state.
+type apiKeyEphemeralResource struct {
+	backendClient *elasticsearch.Client
+}
+
+func (a *apiKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + apiKeyEphemeralResourceTypeName
+}
+
+func (a *apiKeyEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: apiKeyEphemeralResourceDesc,
+		Attributes: map[string]schema.Attribute{
+			nameField: schema.StringAttribute{
+				Description: apiKeyNameFieldDesc,
+				Required:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			expirationField: schema.StringAttribute{
+				Description: expirationFieldDesc,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			idField: schema.StringAttribute{
+				Description: apiKeyIDFieldDesc,
+				Computed:    true,
+			},
+			apiKeyField: schema.StringAttribute{
+				Description: apiKeyFieldDesc,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			encodedField: schema.StringAttribute{
+				Description: encodedFieldDesc,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			expiresAtField: schema.StringAttribute{
+				Description: expiresAtFieldDesc,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (a *apiKeyEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS API key ephemeral resource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Only Elasticsearch has an API to create keys, so the error says so
+	// rather than just naming the backend type required
+	providerBackend := req.ProviderData.(*backend)
+	if providerBackend.elasticsearchClient == nil {
+		resp.Diagnostics.AddError(
+			"API keys not supported by the backend",
+			fmt.Sprintf("The %s%s ephemeral resource creates keys with the Elasticsearch security API, which the backend type '%s' "+
+				"does not provide. Configure the provider with the backend type '%s' to use it.",
+				providerTypeName, apiKeyEphemeralResourceTypeName, providerBackend.backendType, backendTypeElastic),
+		)
+		return
+	}
+
+	a.backendClient = providerBackend.elasticsearchClient
+
+}
+
+func (a *apiKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+
+	var apiKeyConfig APIKeyEphemeralResourceModel
+	diags := req.Config.Get(ctx, &apiKeyConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expiration := expirationDefault
+	if !apiKeyConfig.Expiration.IsNull() {
+		expiration, _ = time.ParseDuration(apiKeyConfig.Expiration.ValueString())
+	}
+
+	backendResponse, err := createAPIKey(ctx, a.backendClient, apiKeyConfig.Name.ValueString(), expiration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating API key",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	// Close only gets the private data, so it needs the key to revoke
+	privateValue, err := json.Marshal(backendResponse.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating API key",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	diags = resp.Private.SetKey(ctx, apiKeyPrivateKey, privateValue)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKeyConfig.Expiration = types.StringValue(expiration.String())
+	apiKeyConfig.ID = types.StringValue(backendResponse.ID)
+	apiKeyConfig.APIKey = types.StringValue(backendResponse.APIKey)
+	apiKeyConfig.Encoded = types.StringValue(backendResponse.Encoded)
+	apiKeyConfig.ExpiresAt = types.StringValue(time.UnixMilli(backendResponse.Expiration).UTC().Format(time.RFC3339))
+
+	diags = resp.Result.Set(ctx, &apiKeyConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (a *apiKeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+
+	privateValue, diags := req.Private.GetKey(ctx, apiKeyPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var apiKeyID string
+	err := json.Unmarshal(privateValue, &apiKeyID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while revoking API key",
+			"The identifier of the API key could not be read. Reason: "+err.Error(),
+		)
+		return
+	}
+
+	err = invalidateAPIKey(ctx, a.backendClient, apiKeyID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while revoking API key",
+			"The API key '"+apiKeyID+"' is left to expire on its own. Reason: "+err.Error(),
+		)
+		return
+	}
+
+}
+
+func createAPIKey(ctx context.Context, backendClient *elasticsearch.Client, name string, expiration time.Duration) (*BackendAPIKeyResponse, error) {
+
+	// In seconds, as the backend does not accept compound durations such as '1h30m0s'
+	bodyContent, err := json.Marshal(map[string]interface{}{
+		"name":       name,
+		"expiration": fmt.Sprintf("%ds", int64(expiration.Seconds())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createRequest := esapi.SecurityCreateAPIKeyRequest{
+		Body: bytes.NewReader(bodyContent),
+	}
+
+	createResponse, err := createRequest.Do(ctx, backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer createResponse.Body.Close()
+
+	backendResponse := &BackendAPIKeyResponse{}
+	err = decodeBackendResponse(createResponse.StatusCode, createResponse.Body, backendResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return backendResponse, nil
+
+}
+
+func invalidateAPIKey(ctx context.Context, backendClient *elasticsearch.Client, apiKeyID string) error {
+
+	bodyContent, err := json.Marshal(map[string]interface{}{
+		"ids": []string{apiKeyID},
+	})
+	if err != nil {
+		return err
+	}
+
+	invalidateRequest := esapi.SecurityInvalidateAPIKeyRequest{
+		Body: bytes.NewReader(bodyContent),
+	}
+
+	invalidateResponse, err := invalidateRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+	defer invalidateResponse.Body.Close()
+
+	backendResponse := &BackendInvalidateAPIKeyResponse{}
+	err = decodeBackendResponse(invalidateResponse.StatusCode, invalidateResponse.Body, backendResponse)
+	if err != nil {
+		return err
+	}
+
+	if len(backendResponse.InvalidatedAPIKeys) == 0 && backendResponse.ErrorCount > 0 {
+		return fmt.Errorf("the backend reported %d errors while invalidating the key", backendResponse.ErrorCount)
+	}
+
+	return nil
+
+}
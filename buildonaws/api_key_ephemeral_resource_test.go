@@ -0,0 +1,113 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAPIKeyEphemeralResource(t *testing.T) {
+
+	ctx := context.Background()
+
+	stub := newBackendStub()
+	stub.infoResult = elasticsearch8InfoResponse
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		backendTypeField:    tftypes.NewValue(tftypes.String, backendTypeElastic),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+		apiKeyField:         tftypes.NewValue(tftypes.String, "Ym9vdHN0cmFwOmtleQ=="),
+	})
+
+	typeName := providerTypeName + apiKeyEphemeralResourceTypeName
+	apiKeySchema := schemaResponse.EphemeralResourceSchemas[typeName]
+	if apiKeySchema == nil {
+		t.Fatalf("expected the %s ephemeral resource to be registered", typeName)
+	}
+
+	openResponse, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config: testDynamicValue(t, apiKeySchema, map[string]tftypes.Value{
+			nameField:       tftypes.NewValue(tftypes.String, "terraform-ci"),
+			expirationField: tftypes.NewValue(tftypes.String, "30m"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range openResponse.Diagnostics {
+		t.Fatalf("open: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	resultValue, err := openResponse.Result.Unmarshal(apiKeySchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := map[string]tftypes.Value{}
+	resultValue.As(&result)
+	var apiKeyID, encoded, expiresAt string
+	result[idField].As(&apiKeyID)
+	result[encodedField].As(&encoded)
+	result[expiresAtField].As(&expiresAt)
+	if !stub.apiKeys[apiKeyID] || encoded == "" || expiresAt == "" {
+		t.Errorf("open: expected an active key with its encoded value and expiration, got id '%s', expires at '%s'", apiKeyID, expiresAt)
+	}
+
+	// The key of the provider configuration authenticates every request
+	for _, authorization := range stub.authorizations {
+		if authorization != "ApiKey Ym9vdHN0cmFwOmtleQ==" {
+			t.Errorf("expected requests authenticated with the configured API key, got '%s'", authorization)
+		}
+	}
+
+	closeResponse, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: typeName,
+		Private:  openResponse.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range closeResponse.Diagnostics {
+		t.Fatalf("close: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+	if stub.apiKeys[apiKeyID] {
+		t.Errorf("close: expected the key '%s' to be revoked", apiKeyID)
+	}
+
+}
+
+func TestAPIKeyEphemeralResourceRequiresElasticsearch(t *testing.T) {
+
+	ctx := context.Background()
+
+	backend := httptest.NewServer(newBackendStub())
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + apiKeyEphemeralResourceTypeName
+	openResponse, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config: testDynamicValue(t, schemaResponse.EphemeralResourceSchemas[typeName], map[string]tftypes.Value{
+			nameField: tftypes.NewValue(tftypes.String, "terraform-ci"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(openResponse.Diagnostics) == 0 || openResponse.Diagnostics[0].Summary != "API keys not supported by the backend" ||
+		!strings.Contains(openResponse.Diagnostics[0].Detail, "'"+backendTypeOpenSearch+"'") {
+		t.Errorf("expected the OpenSearch backend to be rejected, got %+v", openResponse.Diagnostics)
+	}
+
+}
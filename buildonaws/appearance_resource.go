@@ -0,0 +1,327 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ resource.Resource                = &appearanceResource{}
+	_ resource.ResourceWithConfigure   = &appearanceResource{}
+	_ resource.ResourceWithImportState = &appearanceResource{}
+)
+
+func NewAppearanceResource() resource.Resource {
+	return &appearanceResource{}
+}
+
+type appearanceResource struct {
+	backendClient *opensearch.Client
+	refresh       string
+}
+
+func (a *appearanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + appearanceResourceTypeName
+}
+
+func (a *appearanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Description: appearanceIDFieldDesc,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			characterIDField: schema.StringAttribute{
+				Description: characterIDFieldDesc,
+				Required:    true,
+			},
+			seriesField: schema.StringAttribute{
+				Description: seriesFieldDesc,
+				Required:    true,
+			},
+			issueNumberField: schema.Int64Attribute{
+				Description: issueNumberFieldDesc,
+				Required:    true,
+			},
+			publicationDateField: schema.StringAttribute{
+				Description: publicationDateFieldDesc,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"must be a date using the format 'YYYY-MM-DD'",
+					),
+				},
+			},
+			roleField: schema.StringAttribute{
+				Description: roleFieldDesc,
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(appearanceRoles...),
+				},
+			},
+		},
+	}
+}
+
+func (a *appearanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS appearance resource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.backendClient = openSearchClientOf(req.ProviderData, appearanceResourceTypeName, &resp.Diagnostics)
+	a.refresh = req.ProviderData.(*backend).refresh
+
+}
+
+func (a *appearanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root(idField), req, resp)
+}
+
+func (a *appearanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var appearancePlan AppearanceResourceModel
+	diags := req.Plan.Get(ctx, &appearancePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	comicAppearance := &ComicAppearance{
+		CharacterID:     appearancePlan.CharacterID.ValueString(),
+		Series:          appearancePlan.Series.ValueString(),
+		IssueNumber:     appearancePlan.IssueNumber.ValueInt64(),
+		PublicationDate: appearancePlan.PublicationDate.ValueString(),
+		Role:            appearancePlan.Role.ValueString(),
+	}
+	bodyContent, _ := json.Marshal(comicAppearance)
+	bodyReader := bytes.NewReader(bodyContent)
+	indexRequest := opensearchapi.IndexRequest{
+		Index:   appearanceIndex,
+		Body:    bodyReader,
+		Refresh: a.refresh,
+	}
+
+	indexResponse, err := indexRequest.Do(ctx, a.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	defer indexResponse.Body.Close()
+	backendResponse := &BackendAppearanceResponse{}
+	err = decodeBackendResponse(indexResponse.StatusCode, indexResponse.Body, backendResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	appearancePlan.ID = types.StringValue(backendResponse.ID)
+	appearancePlan.PublicationDate = types.StringValue(comicAppearance.PublicationDate)
+
+	diags = resp.State.Set(ctx, appearancePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (a *appearanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var appearanceState AppearanceResourceModel
+	diags := req.State.Get(ctx, &appearanceState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documentID := appearanceState.ID.ValueString()
+
+	getRequest := opensearchapi.GetRequest{
+		Index:      appearanceIndex,
+		DocumentID: documentID,
+	}
+
+	getResponse, err := getRequest.Do(ctx, a.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	defer getResponse.Body.Close()
+	if getResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	backendResponse := &BackendAppearanceResponse{}
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, backendResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	appearanceState.CharacterID = types.StringValue(backendResponse.Source.CharacterID)
+	appearanceState.Series = types.StringValue(backendResponse.Source.Series)
+	appearanceState.IssueNumber = types.Int64Value(backendResponse.Source.IssueNumber)
+	appearanceState.PublicationDate = types.StringValue(backendResponse.Source.PublicationDate)
+	appearanceState.Role = types.StringValue(backendResponse.Source.Role)
+
+	diags = resp.State.Set(ctx, &appearanceState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (a *appearanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var appearancePlan AppearanceResourceModel
+	diags := req.Plan.Get(ctx, &appearancePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documentID := appearancePlan.ID.ValueString()
+
+	// Partial updates keep the fields left out, so a publication date no
+	// longer set is sent as null, as the backend maps it as a date and
+	// rejects empty ones
+	updateBody := &struct {
+		Doc struct {
+			ComicAppearance
+			PublicationDate *string `json:"publication_date"`
+		} `json:"doc"`
+	}{}
+	updateBody.Doc.ComicAppearance = ComicAppearance{
+		CharacterID: appearancePlan.CharacterID.ValueString(),
+		Series:      appearancePlan.Series.ValueString(),
+		IssueNumber: appearancePlan.IssueNumber.ValueInt64(),
+		Role:        appearancePlan.Role.ValueString(),
+	}
+	if publicationDate := appearancePlan.PublicationDate.ValueString(); publicationDate != "" {
+		updateBody.Doc.PublicationDate = &publicationDate
+	}
+
+	bodyContent, err := json.Marshal(updateBody)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while marshalling request",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	bodyReader := bytes.NewReader(bodyContent)
+	updateRequest := opensearchapi.UpdateRequest{
+		Index:      appearanceIndex,
+		DocumentID: documentID,
+		Body:       bodyReader,
+		Refresh:    a.refresh,
+	}
+
+	updateResponse, err := updateRequest.Do(ctx, a.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while updating appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	defer updateResponse.Body.Close()
+	err = decodeBackendResponse(updateResponse.StatusCode, updateResponse.Body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while updating appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	appearancePlan.PublicationDate = types.StringValue(appearancePlan.PublicationDate.ValueString())
+
+	diags = resp.State.Set(ctx, appearancePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (a *appearanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var appearanceState AppearanceResourceModel
+	diags := req.State.Get(ctx, &appearanceState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documentID := appearanceState.ID.ValueString()
+	deleteRequest := opensearchapi.DeleteRequest{
+		Index:      appearanceIndex,
+		DocumentID: documentID,
+		Refresh:    a.refresh,
+	}
+	deleteResponse, err := deleteRequest.Do(ctx, a.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while deleting appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	// An appearance already deleted outside Terraform is gone either way
+	defer deleteResponse.Body.Close()
+	if deleteResponse.StatusCode == http.StatusNotFound {
+		return
+	}
+
+	err = decodeBackendResponse(deleteResponse.StatusCode, deleteResponse.Body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while deleting appearance",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+}
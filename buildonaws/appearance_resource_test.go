@@ -0,0 +1,225 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAppearanceResource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	appearance := &ComicAppearance{
+		CharacterID:     "daredevil",
+		Series:          "Daredevil",
+		IssueNumber:     1,
+		PublicationDate: "1964-04-01",
+		Role:            appearanceRoles[0],
+	}
+
+	terraformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	resource "buildonaws_appearance" "daredevil_1" {
+		character_id = "${appearance_character_id}"
+		series = "${appearance_series}"
+		issue_number = ${appearance_issue_number}
+		publication_date = "${appearance_publication_date}"
+		role = "${appearance_role}"
+	}`
+
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${backend_address}", backendContainer.Address)
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${appearance_character_id}", appearance.CharacterID)
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${appearance_series}", appearance.Series)
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${appearance_issue_number}", strconv.FormatInt(appearance.IssueNumber, 10))
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${appearance_publication_date}", appearance.PublicationDate)
+
+	tfConfigCreateReadTest := strings.ReplaceAll(terraformConfig, "${appearance_role}", appearance.Role)
+	tfConfigUpdateReadTest := strings.ReplaceAll(terraformConfig, "${appearance_role}", appearanceRoles[2])
+
+	resourceName := "buildonaws_appearance.daredevil_1"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: tfConfigCreateReadTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify if the fields match with what is expected
+					resource.TestCheckResourceAttr(resourceName, characterIDField, appearance.CharacterID),
+					resource.TestCheckResourceAttr(resourceName, seriesField, appearance.Series),
+					resource.TestCheckResourceAttr(resourceName, issueNumberField, strconv.FormatInt(appearance.IssueNumber, 10)),
+					resource.TestCheckResourceAttr(resourceName, publicationDateField, appearance.PublicationDate),
+					resource.TestCheckResourceAttr(resourceName, roleField, appearance.Role),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet(resourceName, idField),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: tfConfigUpdateReadTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, roleField, appearanceRoles[2]),
+					resource.TestCheckResourceAttrSet(resourceName, idField),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+
+}
+
+func TestAppearanceResourceBackendErrors(t *testing.T) {
+
+	stub := newBackendStub()
+	stub.documents["1"] = &ComicCharacter{}
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + appearanceResourceTypeName
+	appearance := func(documentID string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			idField:              tftypes.NewValue(tftypes.String, documentID),
+			characterIDField:     tftypes.NewValue(tftypes.String, "42"),
+			seriesField:          tftypes.NewValue(tftypes.String, "Daredevil"),
+			issueNumberField:     tftypes.NewValue(tftypes.Number, 1),
+			publicationDateField: tftypes.NewValue(tftypes.String, "1964-04-01"),
+			roleField:            tftypes.NewValue(tftypes.String, appearanceRoles[0]),
+		}
+	}
+
+	diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName, appearance("1"), appearance("1"))
+	for _, diagnostic := range diagnostics {
+		t.Errorf("update: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	diagnostics = applyTestResourceChange(t, server, schemaResponse, typeName, appearance("2"), appearance("2"))
+	if len(diagnostics) == 0 || !strings.Contains(diagnostics[0].Detail, "404") {
+		t.Errorf("update: expected the missing appearance to be reported, got %+v", diagnostics)
+	}
+
+	// Deleting an appearance already gone is not an error
+	for _, documentID := range []string{"1", "2"} {
+		diagnostics = applyTestResourceChange(t, server, schemaResponse, typeName, appearance(documentID), nil)
+		for _, diagnostic := range diagnostics {
+			t.Errorf("delete '%s': unexpected diagnostic: %s: %s", documentID, diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	if len(stub.documents) != 0 {
+		t.Errorf("delete: expected the appearance to be deleted, got %+v", stub.documents)
+	}
+
+}
+
+func TestAppearanceResourceBackendDenied(t *testing.T) {
+
+	backend := newFailingBackend(http.StatusForbidden)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + appearanceResourceTypeName
+	appearance := func(documentID tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			idField:              documentID,
+			characterIDField:     tftypes.NewValue(tftypes.String, "42"),
+			seriesField:          tftypes.NewValue(tftypes.String, "Daredevil"),
+			issueNumberField:     tftypes.NewValue(tftypes.Number, 1),
+			publicationDateField: tftypes.NewValue(tftypes.String, "1964-04-01"),
+			roleField:            tftypes.NewValue(tftypes.String, appearanceRoles[0]),
+		}
+	}
+
+	diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName, nil,
+		appearance(tftypes.NewValue(tftypes.String, tftypes.UnknownValue)))
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while creating appearance" || !strings.Contains(diagnostics[0].Detail, "403") {
+		t.Errorf("create: expected the backend error to be reported, got %+v", diagnostics)
+	}
+
+	diagnostics = readTestResource(t, server, schemaResponse, typeName, appearance(tftypes.NewValue(tftypes.String, "1")))
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while reading appearance" || !strings.Contains(diagnostics[0].Detail, "403") {
+		t.Errorf("read: expected the backend error to be reported, got %+v", diagnostics)
+	}
+
+}
+
+func TestAppearanceResourceClearsFields(t *testing.T) {
+
+	var updateBody []byte
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		updateBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id": "1", "result": "updated"}`))
+	}))
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + appearanceResourceTypeName
+	appearance := func(issueNumber int64, publicationDate tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			idField:              tftypes.NewValue(tftypes.String, "1"),
+			characterIDField:     tftypes.NewValue(tftypes.String, "42"),
+			seriesField:          tftypes.NewValue(tftypes.String, "Daredevil"),
+			issueNumberField:     tftypes.NewValue(tftypes.Number, issueNumber),
+			publicationDateField: publicationDate,
+			roleField:            tftypes.NewValue(tftypes.String, appearanceRoles[0]),
+		}
+	}
+
+	diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName,
+		appearance(1, tftypes.NewValue(tftypes.String, "1964-04-01")),
+		appearance(0, tftypes.NewValue(tftypes.String, nil)))
+	for _, diagnostic := range diagnostics {
+		t.Errorf("update: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	document := &struct {
+		Doc map[string]interface{} `json:"doc"`
+	}{}
+	err := json.Unmarshal(updateBody, document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publicationDate, found := document.Doc[publicationDateField]; !found || publicationDate != nil {
+		t.Errorf("update: expected the publication date to be cleared, got %s", updateBody)
+	}
+	if issueNumber, found := document.Doc[issueNumberField]; !found || issueNumber != float64(0) {
+		t.Errorf("update: expected the issue number to be sent, got %s", updateBody)
+	}
+
+}
@@ -0,0 +1,174 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/opensearch-project/opensearch-go/v2"
+)
+
+// backend is handed over by the provider to data sources and resources.
+// The OpenSearch client is only set when the backend type is OpenSearch,
+// as some resources rely on APIs that only OpenSearch provides, and the
+// same goes for the Elasticsearch client.
+type backend struct {
+	backendType         string
+	openSearchClient    *opensearch.Client
+	elasticsearchClient *elasticsearch.Client
+	characterStore      characterStore
+	refresh             string
+	fullNamePattern     *regexp.Regexp
+	maxTextLength       int
+	uniqueFullNames     bool
+	address             string
+	transport           http.RoundTripper
+	connectMode         string
+	clusterMutex        sync.Mutex
+	cluster             string
+}
+
+// newBackend creates the backend for the given type. The address is the
+// path where characters are stored when the backend type is 'file'. The
+// refresh policy is applied to every document written to the backend.
+// Retries are left to the transport, so the clients never retry by
+// themselves.
+func newBackend(backendType string, address string, refresh string, transport http.RoundTripper) (*backend, error) {
+
+	switch backendType {
+
+	case backendTypeFile:
+		store, err := newFileCharacterStore(address)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{
+			backendType:    backendType,
+			characterStore: store,
+		}, nil
+
+	case backendTypeElastic:
+		backendClient, err := elasticsearch.NewClient(
+			elasticsearch.Config{
+				Addresses:    []string{address},
+				Transport:    transport,
+				DisableRetry: true,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{
+			backendType:         backendType,
+			elasticsearchClient: backendClient,
+			characterStore:      &elasticsearchCharacterStore{backendClient: backendClient, refresh: refresh},
+			refresh:             refresh,
+			address:             address,
+			transport:           transport,
+		}, nil
+
+	default:
+		backendClient, err := opensearch.NewClient(
+			opensearch.Config{
+				Addresses:    []string{address},
+				Transport:    transport,
+				DisableRetry: true,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{
+			backendType:      backendType,
+			openSearchClient: backendClient,
+			characterStore:   &openSearchCharacterStore{backendClient: backendClient, refresh: refresh},
+			refresh:          refresh,
+			address:          address,
+			transport:        transport,
+		}, nil
+
+	}
+
+}
+
+// clusterName returns the name of the cluster where characters are stored,
+// asking the backend for it the first time unless it is already known from
+// the connectivity check. The file backend has no cluster, so it has no name.
+// The backend is never verified when connecting is skipped, so only the
+// name is read from the cluster info then.
+func (b *backend) clusterName(ctx context.Context) (string, error) {
+
+	if b.backendType == backendTypeFile {
+		return "", nil
+	}
+
+	b.clusterMutex.Lock()
+	defer b.clusterMutex.Unlock()
+
+	if b.cluster != "" {
+		return b.cluster, nil
+	}
+
+	bodyContent, err := requestBackendInfo(ctx, b.transport, b.address, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if b.connectMode == connectModeSkip {
+		backendInfoResponse := &BackendInfoResponse{}
+		err = json.Unmarshal(bodyContent, backendInfoResponse)
+		if err != nil {
+			return "", fmt.Errorf("the backend did not return a valid cluster info response: %w", err)
+		}
+		b.cluster = backendInfoResponse.ClusterName
+		return b.cluster, nil
+	}
+
+	info, err := checkBackendCompatibility(bodyContent, b.backendType)
+	if err != nil {
+		return "", err
+	}
+
+	b.cluster = info.ClusterName
+	return b.cluster, nil
+
+}
+
+// openSearchClientOf returns the OpenSearch client from the provider data,
+// adding an error when the provider was configured with another backend.
+func openSearchClientOf(providerData interface{}, typeName string, diags *diag.Diagnostics) *opensearch.Client {
+
+	providerBackend := providerData.(*backend)
+	if providerBackend.openSearchClient == nil {
+		diags.AddError(
+			"Unsupported backend type",
+			fmt.Sprintf("The %s%s type requires the backend type '%s', but the provider is configured with '%s'.",
+				providerTypeName, typeName, backendTypeOpenSearch, providerBackend.backendType),
+		)
+	}
+
+	return providerBackend.openSearchClient
+
+}
+
+// elasticsearchClientOf returns the Elasticsearch client from the provider
+// data, adding an error when the provider was configured with another backend.
+func elasticsearchClientOf(providerData interface{}, typeName string, diags *diag.Diagnostics) *elasticsearch.Client {
+
+	providerBackend := providerData.(*backend)
+	if providerBackend.elasticsearchClient == nil {
+		diags.AddError(
+			"Unsupported backend type",
+			fmt.Sprintf("The %s%s type requires the backend type '%s', but the provider is configured with '%s'.",
+				providerTypeName, typeName, backendTypeElastic, providerBackend.backendType),
+		)
+	}
+
+	return providerBackend.elasticsearchClient
+
+}
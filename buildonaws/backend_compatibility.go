@@ -0,0 +1,165 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	distributionOpenSearch    = "opensearch"
+	distributionElasticsearch = "elasticsearch"
+)
+
+// supportedBackends holds the range of versions, per distribution,
+// that the provider knows how to talk to. The minimum version is
+// inclusive and the maximum version is exclusive.
+var supportedBackends = map[string]struct {
+	minimum backendVersion
+	maximum backendVersion
+}{
+	distributionOpenSearch: {
+		minimum: backendVersion{Major: 2},
+		maximum: backendVersion{Major: 4},
+	},
+	distributionElasticsearch: {
+		minimum: backendVersion{Major: 8},
+		maximum: backendVersion{Major: 9},
+	},
+}
+
+type backendVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func parseBackendVersion(version string) (backendVersion, error) {
+
+	var parsedVersion backendVersion
+
+	// Drop qualifiers such as '-SNAPSHOT' or '-rc1'
+	numbers := strings.SplitN(version, "-", 2)[0]
+	parts := strings.Split(numbers, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return parsedVersion, fmt.Errorf("invalid version '%s'", version)
+	}
+
+	fields := []*int{&parsedVersion.Major, &parsedVersion.Minor, &parsedVersion.Patch}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return parsedVersion, fmt.Errorf("invalid version '%s'", version)
+		}
+		*fields[i] = number
+	}
+
+	return parsedVersion, nil
+
+}
+
+func (v backendVersion) lessThan(other backendVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+func (v backendVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// backendInfo describes the backend the provider is connected to,
+// as reported by the root endpoint of the cluster.
+type backendInfo struct {
+	ClusterName  string
+	Distribution string
+	Version      backendVersion
+}
+
+// requestBackendInfo retrieves the cluster info from the root endpoint of
+// the backend, which is also how the provider verifies the connectivity.
+// The root endpoint is relative to the path of the address, as backends
+// behind a reverse proxy are often served under a path prefix.
+func requestBackendInfo(ctx context.Context, transport http.RoundTripper, address string, header http.Header) ([]byte, error) {
+
+	infoURL, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	infoURL.Path = strings.TrimSuffix(infoURL.Path, "/") + "/"
+	infoURL.RawPath = ""
+
+	infoRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, infoURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		infoRequest.Header[name] = values
+	}
+
+	infoResponse, err := transport.RoundTrip(infoRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer infoResponse.Body.Close()
+
+	if infoResponse.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("unexpected status '%s' from the backend", infoResponse.Status)
+	}
+
+	return io.ReadAll(infoResponse.Body)
+
+}
+
+// checkBackendCompatibility parses the response of the root endpoint and
+// verifies whether the backend is the expected distribution and whether
+// its version is within the supported range. The requests sent are the
+// same across the supported range, so the version does not change them.
+func checkBackendCompatibility(infoResponse []byte, expectedDistribution string) (*backendInfo, error) {
+
+	backendInfoResponse := &BackendInfoResponse{}
+	err := json.Unmarshal(infoResponse, backendInfoResponse)
+	if err != nil {
+		return nil, fmt.Errorf("the backend did not return a valid cluster info response: %w", err)
+	}
+
+	distribution := backendInfoResponse.Version.Distribution
+	if distribution == "" {
+		// Elasticsearch does not report its distribution
+		distribution = distributionElasticsearch
+	}
+
+	version, err := parseBackendVersion(backendInfoResponse.Version.Number)
+	if err != nil {
+		return nil, fmt.Errorf("the backend reported an unexpected version: %w", err)
+	}
+
+	info := &backendInfo{
+		ClusterName:  backendInfoResponse.ClusterName,
+		Distribution: distribution,
+		Version:      version,
+	}
+
+	supportedRange := supportedBackends[expectedDistribution]
+	if distribution != expectedDistribution {
+		return info, fmt.Errorf("the backend is '%s' (version %s), but the provider is configured to use '%s'",
+			distribution, version, expectedDistribution)
+	}
+
+	if version.lessThan(supportedRange.minimum) || !version.lessThan(supportedRange.maximum) {
+		return info, fmt.Errorf("the version %s of '%s' is not supported by this provider. Supported versions: >= %s and < %s",
+			version, distribution, supportedRange.minimum, supportedRange.maximum)
+	}
+
+	return info, nil
+
+}
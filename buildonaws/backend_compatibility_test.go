@@ -0,0 +1,189 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	openSearch2InfoResponse = `{
+		"cluster_name": "opensearch-cluster",
+		"version": {"distribution": "opensearch", "number": "2.14.0"}
+	}`
+	openSearch1InfoResponse = `{
+		"cluster_name": "opensearch-cluster",
+		"version": {"distribution": "opensearch", "number": "1.3.16"}
+	}`
+	elasticsearch8InfoResponse = `{
+		"cluster_name": "elasticsearch-cluster",
+		"version": {"number": "8.11.1", "build_flavor": "default"}
+	}`
+)
+
+func TestCheckBackendCompatibility(t *testing.T) {
+
+	testCases := []struct {
+		name         string
+		infoResponse string
+		backendType  string
+		distribution string
+		version      string
+		expectError  string
+	}{
+		{
+			name:         "supported opensearch",
+			infoResponse: openSearch2InfoResponse,
+			backendType:  backendTypeOpenSearch,
+			distribution: distributionOpenSearch,
+			version:      "2.14.0",
+		},
+		{
+			name:         "opensearch too old",
+			infoResponse: openSearch1InfoResponse,
+			backendType:  backendTypeOpenSearch,
+			distribution: distributionOpenSearch,
+			version:      "1.3.16",
+			expectError:  "the version 1.3.16 of 'opensearch' is not supported",
+		},
+		{
+			name:         "supported elasticsearch",
+			infoResponse: elasticsearch8InfoResponse,
+			backendType:  backendTypeElastic,
+			distribution: distributionElasticsearch,
+			version:      "8.11.1",
+		},
+		{
+			name:         "elasticsearch instead of opensearch",
+			infoResponse: elasticsearch8InfoResponse,
+			backendType:  backendTypeOpenSearch,
+			distribution: distributionElasticsearch,
+			version:      "8.11.1",
+			expectError:  "the backend is 'elasticsearch' (version 8.11.1), but the provider is configured to use 'opensearch'",
+		},
+		{
+			name:         "opensearch instead of elasticsearch",
+			infoResponse: openSearch2InfoResponse,
+			backendType:  backendTypeElastic,
+			distribution: distributionOpenSearch,
+			version:      "2.14.0",
+			expectError:  "the backend is 'opensearch' (version 2.14.0), but the provider is configured to use 'elasticsearch'",
+		},
+		{
+			name:         "snapshot version",
+			infoResponse: `{"version": {"distribution": "opensearch", "number": "3.0.0-SNAPSHOT"}}`,
+			backendType:  backendTypeOpenSearch,
+			distribution: distributionOpenSearch,
+			version:      "3.0.0",
+		},
+		{
+			name:         "invalid version",
+			infoResponse: `{"version": {"distribution": "opensearch", "number": "latest"}}`,
+			backendType:  backendTypeOpenSearch,
+			expectError:  "the backend reported an unexpected version",
+		},
+		{
+			name:         "not a cluster",
+			infoResponse: `<html>Welcome to nginx</html>`,
+			backendType:  backendTypeOpenSearch,
+			expectError:  "the backend did not return a valid cluster info response",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			info, err := checkBackendCompatibility([]byte(testCase.infoResponse), testCase.backendType)
+
+			if testCase.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectError) {
+					t.Fatalf("expected error containing %q, got: %v", testCase.expectError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if testCase.distribution != "" && info.Distribution != testCase.distribution {
+				t.Errorf("expected distribution %q, got %q", testCase.distribution, info.Distribution)
+			}
+			if testCase.version != "" && info.Version.String() != testCase.version {
+				t.Errorf("expected version %q, got %q", testCase.version, info.Version.String())
+			}
+
+		})
+	}
+
+}
+
+func TestRequestBackendInfo(t *testing.T) {
+
+	var requestedPaths []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		w.Write([]byte(openSearch2InfoResponse))
+	}))
+	defer backend.Close()
+
+	testCases := map[string]string{
+		"":         "/",
+		"/":        "/",
+		"/search":  "/search/",
+		"/search/": "/search/",
+	}
+
+	for addressPath, expectedPath := range testCases {
+		requestedPaths = nil
+		_, err := requestBackendInfo(context.Background(), http.DefaultTransport, backend.URL+addressPath, nil)
+		if err != nil {
+			t.Fatalf("address path '%s': unexpected error: %v", addressPath, err)
+		}
+		if len(requestedPaths) != 1 || requestedPaths[0] != expectedPath {
+			t.Errorf("address path '%s': expected the info to be requested from '%s', got %v", addressPath, expectedPath, requestedPaths)
+		}
+	}
+
+}
+
+func TestBackendClusterName(t *testing.T) {
+
+	stub := newBackendStub()
+	// Another distribution than the one configured, out of the supported range
+	stub.infoResult = elasticsearch8InfoResponse
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	testCases := map[string]struct {
+		connectMode string
+		cluster     string
+		err         string
+	}{
+		"verified": {connectMode: connectModeLazy, err: "configured to use 'opensearch'"},
+		"skipped":  {connectMode: connectModeSkip, cluster: "elasticsearch-cluster"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			providerBackend, err := newBackend(backendTypeOpenSearch, backend.URL, refreshWaitFor, http.DefaultTransport)
+			if err != nil {
+				t.Fatal(err)
+			}
+			providerBackend.connectMode = testCase.connectMode
+
+			cluster, err := providerBackend.clusterName(context.Background())
+			if testCase.err != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.err) {
+					t.Errorf("expected an error containing '%s', got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil || cluster != testCase.cluster {
+				t.Errorf("expected the cluster '%s', got '%s', %v", testCase.cluster, cluster, err)
+			}
+
+		})
+	}
+
+}
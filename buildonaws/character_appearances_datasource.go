@@ -0,0 +1,177 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ datasource.DataSource              = &characterAppearancesDataSource{}
+	_ datasource.DataSourceWithConfigure = &characterAppearancesDataSource{}
+)
+
+func NewCharacterAppearancesDataSource() datasource.DataSource {
+	return &characterAppearancesDataSource{}
+}
+
+type characterAppearancesDataSource struct {
+	backendClient *opensearch.Client
+}
+
+func (c *characterAppearancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + characterAppearancesDataSourceTypeName
+}
+
+func (c *characterAppearancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Computed: true,
+			},
+			characterIDField: schema.StringAttribute{
+				Description: characterIDFilterFieldDesc,
+				Optional:    true,
+			},
+			appearancesField: schema.ListNestedAttribute{
+				Description: appearancesFieldDesc,
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						characterIDField: schema.StringAttribute{
+							Description: idFieldDesc,
+							Computed:    true,
+						},
+						countField: schema.Int64Attribute{
+							Description: countFieldDesc,
+							Computed:    true,
+						},
+						rolesField: schema.MapAttribute{
+							Description: rolesFieldDesc,
+							ElementType: types.Int64Type,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *characterAppearancesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS character appearances datasource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	c.backendClient = openSearchClientOf(req.ProviderData, characterAppearancesDataSourceTypeName, &resp.Diagnostics)
+
+}
+
+func (c *characterAppearancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var appearancesConfig CharacterAppearancesDataSourceModel
+	diags := req.Config.Get(ctx, &appearancesConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	searchBody := map[string]interface{}{
+		"size": 0,
+		"aggs": map[string]interface{}{
+			"characters": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": characterIDField + ".keyword",
+					"size":  aggregationBucketsSize,
+				},
+				"aggs": map[string]interface{}{
+					"roles": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": roleField + ".keyword",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	filterID := "all"
+	if !appearancesConfig.CharacterID.IsNull() {
+		filterID = appearancesConfig.CharacterID.ValueString()
+		searchBody["query"] = map[string]interface{}{
+			"term": map[string]interface{}{
+				characterIDField + ".keyword": filterID,
+			},
+		}
+	}
+
+	bodyContent, _ := json.Marshal(searchBody)
+	bodyReader := bytes.NewReader(bodyContent)
+	ignoreUnavailable := true
+	searchRequest := opensearchapi.SearchRequest{
+		Index:             []string{appearanceIndex},
+		Body:              bodyReader,
+		IgnoreUnavailable: &ignoreUnavailable,
+	}
+
+	searchResponse, err := searchRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while aggregating appearances",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	defer searchResponse.Body.Close()
+
+	bodyContent, err = io.ReadAll(searchResponse.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading response",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	aggregationResponse := &BackendAppearancesAggregationResponse{}
+	err = json.Unmarshal(bodyContent, aggregationResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while unmarshalling response",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	appearancesConfig.ID = types.StringValue(filterID)
+	appearancesConfig.Appearances = []CharacterAppearanceCountModel{}
+	for _, bucket := range aggregationResponse.Aggregations.Characters.Buckets {
+		roles := make(map[string]types.Int64, len(bucket.Roles.Buckets))
+		for _, roleBucket := range bucket.Roles.Buckets {
+			roles[roleBucket.Key] = types.Int64Value(roleBucket.DocCount)
+		}
+		appearancesConfig.Appearances = append(appearancesConfig.Appearances, CharacterAppearanceCountModel{
+			CharacterID: types.StringValue(bucket.Key),
+			Count:       types.Int64Value(bucket.DocCount),
+			Roles:       roles,
+		})
+	}
+
+	diags = resp.State.Set(ctx, &appearancesConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
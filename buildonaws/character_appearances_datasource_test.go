@@ -0,0 +1,100 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+func TestAccCharacterAppearancesDataSource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	appearances := []*ComicAppearance{
+		{CharacterID: "wolverine", Series: "The Incredible Hulk", IssueNumber: 180, Role: appearanceRoles[2]},
+		{CharacterID: "wolverine", Series: "The Incredible Hulk", IssueNumber: 181, Role: appearanceRoles[1]},
+		{CharacterID: "wolverine", Series: "Wolverine", IssueNumber: 1, Role: appearanceRoles[0]},
+	}
+
+	for _, appearance := range appearances {
+		err = createAppearance(ctx, appearance, backendContainer)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	data "buildonaws_character_appearances" "wolverine" {
+		character_id = "wolverine"
+	}`
+
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${backend_address}", backendContainer.Address)
+
+	dataSourceName := "data.buildonaws_character_appearances.wolverine"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: terrformConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify if the aggregated counts match with what is expected
+					resource.TestCheckResourceAttr(dataSourceName, appearancesField+".#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, appearancesField+".0."+characterIDField, "wolverine"),
+					resource.TestCheckResourceAttr(dataSourceName, appearancesField+".0."+countField, "3"),
+					resource.TestCheckResourceAttr(dataSourceName, appearancesField+".0."+rolesField+".lead", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, appearancesField+".0."+rolesField+".cameo", "1"),
+				),
+			},
+		},
+	})
+
+}
+
+func createAppearance(ctx context.Context, appearance *ComicAppearance,
+	backendContainer *backendContainer) error {
+
+	backendClient, err := opensearch.NewClient(
+		opensearch.Config{
+			Addresses: []string{backendContainer.Address},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	bodyContent, err := json.Marshal(appearance)
+	if err != nil {
+		return err
+	}
+
+	bodyReader := bytes.NewReader(bodyContent)
+	indexRequest := opensearchapi.IndexRequest{
+		Index:   appearanceIndex,
+		Body:    bodyReader,
+		Refresh: "wait_for",
+	}
+
+	_, err = indexRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
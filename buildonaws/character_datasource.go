@@ -1,17 +1,12 @@
 package buildonaws
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/opensearch-project/opensearch-go/v2"
-	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 )
 
 var (
@@ -24,7 +19,7 @@ func NewCharacterDataSource() datasource.DataSource {
 }
 
 type characterDataSource struct {
-	backendClient *opensearch.Client
+	characterStore characterStore
 }
 
 func (c *characterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,6 +41,7 @@ func (c *characterDataSource) Schema(ctx context.Context, req datasource.SchemaR
 			identityField: schema.StringAttribute{
 				Description: identityFieldDesc,
 				Required:    true,
+				Sensitive:   true,
 			},
 			knownasField: schema.StringAttribute{
 				Description: knowasFieldDesc,
@@ -57,6 +53,15 @@ func (c *characterDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Optional:    true,
 				Computed:    true,
 			},
+			universeField: schema.StringAttribute{
+				Description: universeFieldDesc,
+				Computed:    true,
+			},
+			tagsField: schema.SetAttribute{
+				Description: tagsFieldDesc,
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -69,7 +74,7 @@ func (c *characterDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	c.backendClient = req.ProviderData.(*opensearch.Client)
+	c.characterStore = req.ProviderData.(*backend).characterStore
 
 }
 
@@ -82,65 +87,35 @@ func (c *characterDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	searchBody := &struct {
-		Query struct {
-			Match struct {
-				Identity string `json:"identity,omitempty"`
-			} `json:"match,omitempty"`
-		} `json:"query,omitempty"`
-	}{}
-
-	searchBody.Query.Match.Identity = characterPlan.Identity.ValueString()
-	bodyContent, _ := json.Marshal(searchBody)
-	bodyReader := bytes.NewReader(bodyContent)
-	searchRequest := opensearchapi.SearchRequest{
-		Index: []string{backendIndex},
-		Body:  bodyReader,
-	}
-
-	searchResponse, err := searchRequest.Do(ctx, c.backendClient)
-	if err != nil {
+	character, err := c.characterStore.FindCharacterByIdentity(ctx, characterPlan.Identity.ValueString())
+	if err != nil && err != errCharacterNotFound {
 		resp.Diagnostics.AddError(
 			"Error while retrieving character",
 			"Reason: "+err.Error(),
 		)
-	}
-	defer searchResponse.Body.Close()
-
-	bodyContent, err = io.ReadAll(searchResponse.Body)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while reading response",
-			"Reason: "+err.Error(),
-		)
-	}
-
-	backendSearchResponse := &BackendSearchResponse{}
-	err = json.Unmarshal(bodyContent, backendSearchResponse)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while unmarshalling response",
-			"Reason: "+err.Error(),
-		)
 		return
 	}
 
-	if backendSearchResponse.Hits.Total.Value > 0 {
-		character := backendSearchResponse.Hits.Hits[0].Source
+	if err == nil {
 		characterPlan.ID = types.StringValue(character.ID)
 		characterPlan.FullName = types.StringValue(character.FullName)
 		characterPlan.Identity = types.StringValue(character.Identity)
 		characterPlan.KnownAs = types.StringValue(character.KnownAs)
 		characterPlan.Type = types.StringValue(character.Type)
+		characterPlan.Universe = types.StringValue(character.Universe)
+		characterPlan.Tags, diags = types.SetValueFrom(ctx, types.StringType, nonNilTags(character.Tags))
+		resp.Diagnostics.Append(diags...)
 	} else {
 		var emptyString string
 		characterPlan.ID = types.StringValue(emptyString)
 		characterPlan.FullName = types.StringValue(emptyString)
 		characterPlan.KnownAs = types.StringValue(emptyString)
 		characterPlan.Type = types.StringValue(emptyString)
+		characterPlan.Universe = types.StringValue(emptyString)
+		characterPlan.Tags = types.SetValueMust(types.StringType, nil)
 		resp.Diagnostics.AddWarning(
 			"Datasource was not loaded",
-			"Reason: no character with the identity '"+characterPlan.Identity.ValueString()+"'.",
+			"Reason: no character with the identity given.",
 		)
 	}
 
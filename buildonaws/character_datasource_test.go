@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
@@ -64,6 +67,55 @@ func TestAccCharacterDataSource(t *testing.T) {
 
 }
 
+func TestAccCharacterDataSourceAfterCreate(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// The data source looks the character up in the same apply that creates
+	// it, which only works when writes wait for the index to be refreshed
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+		refresh = "${refresh}"
+	}
+
+	resource "buildonaws_character" "wolverine" {
+		fullname = "Wolverine"
+		identity = "James Howlett"
+		knownas = "Logan"
+		type = "anti-hero"
+	}
+
+	data "buildonaws_character" "wolverine" {
+		identity = buildonaws_character.wolverine.identity
+	}`
+
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${backend_address}", backendContainer.Address)
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${refresh}", refreshWaitFor)
+
+	dataSourceName := "data.buildonaws_character.wolverine"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: terrformConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, idField, "buildonaws_character.wolverine", idField),
+					resource.TestCheckResourceAttr(dataSourceName, fullNameField, "Wolverine"),
+					resource.TestCheckResourceAttr(dataSourceName, knownasField, "Logan"),
+				),
+			},
+		},
+	})
+
+}
+
 func createCharacter(ctx context.Context, character *ComicCharacter,
 	backendContainer *backendContainer) error {
 
@@ -96,3 +148,34 @@ func createCharacter(ctx context.Context, character *ComicCharacter,
 	return nil
 
 }
+
+func TestCharacterDataSourceNotFound(t *testing.T) {
+
+	backend := httptest.NewServer(newBackendStub())
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + characterDataSourceTypeName
+	readResponse, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config: testDynamicValue(t, schemaResponse.DataSourceSchemas[typeName], map[string]tftypes.Value{
+			identityField: tftypes.NewValue(tftypes.String, "Matt Murdock"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The identity is sensitive, so it is left out of the warning
+	if len(readResponse.Diagnostics) != 1 || readResponse.Diagnostics[0].Summary != "Datasource was not loaded" {
+		t.Fatalf("expected a single warning, got %+v", readResponse.Diagnostics)
+	}
+	if strings.Contains(readResponse.Diagnostics[0].Detail, "Murdock") {
+		t.Errorf("expected the identity to be left out of the warning, got '%s'", readResponse.Diagnostics[0].Detail)
+	}
+
+}
@@ -0,0 +1,152 @@
+package buildonaws
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &characterListResource{}
+	_ list.ListResourceWithConfigure = &characterListResource{}
+)
+
+func NewCharacterListResource() list.ListResource {
+	return &characterListResource{}
+}
+
+// characterListResource enumerates the characters stored in the backend,
+// so the ones not managed yet can be discovered with 'terraform query'.
+type characterListResource struct {
+	backend        *backend
+	characterStore characterStore
+}
+
+func (c *characterListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + characterResourceTypeName
+}
+
+func (c *characterListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			fullNameField: schema.StringAttribute{
+				Description: fullNameFilterFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			typeField: schema.StringAttribute{
+				Description: typeFilterFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			universeField: schema.StringAttribute{
+				Description: universeFilterFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (c *characterListResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS list resource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	backend := req.ProviderData.(*backend)
+	c.backend = backend
+	c.characterStore = backend.characterStore
+
+}
+
+func (c *characterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+
+	var listConfig CharacterListConfigModel
+	diags := req.Config.Get(ctx, &listConfig)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := characterFilter{
+		FullName: listConfig.FullName.ValueString(),
+		Type:     listConfig.Type.ValueString(),
+		Universe: listConfig.Universe.ValueString(),
+	}
+
+	// Every character listed is stored in the same cluster
+	var clusterDiags diag.Diagnostics
+	clusterIdentity := characterIdentity(ctx, c.backend, "", &clusterDiags)
+	if clusterDiags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(clusterDiags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+
+		var listed int64
+		err := c.characterStore.ListCharacters(ctx, filter, listPageSize, func(characters []*ComicCharacter) bool {
+			for _, character := range characters {
+				if !filter.matches(character) {
+					continue
+				}
+				if listed >= req.Limit || !push(c.listResult(ctx, req, clusterIdentity, character)) {
+					return false
+				}
+				listed++
+			}
+			return true
+		})
+		if err != nil {
+			result := req.NewListResult(ctx)
+			result.Diagnostics.AddError(
+				"Error while listing characters",
+				"Reason: "+err.Error(),
+			)
+			push(result)
+		}
+
+	}
+
+}
+
+func (c *characterListResource) listResult(ctx context.Context, req list.ListRequest, identity CharacterIdentityModel, character *ComicCharacter) list.ListResult {
+
+	result := req.NewListResult(ctx)
+	result.DisplayName = character.FullName
+
+	identity.DocumentID = types.StringValue(character.ID)
+	result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+	if result.Diagnostics.HasError() || !req.IncludeResource {
+		return result
+	}
+
+	// Timeouts are not stored in the backend, so they are left null
+	var characterModel CharacterResourceModel
+	result.Diagnostics.Append(result.Resource.GetAttribute(ctx, path.Root(timeoutsField), &characterModel.Timeouts)...)
+	result.Diagnostics.Append(characterResourceModelOf(ctx, &characterModel, character)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	result.Diagnostics.Append(result.Resource.Set(ctx, &characterModel)...)
+
+	return result
+
+}
@@ -0,0 +1,156 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCharacterListResource(t *testing.T) {
+
+	ctx := context.Background()
+
+	stub := newBackendStub()
+	for i, character := range []*ComicCharacter{
+		{FullName: "Spider-Man", Identity: "Peter Parker", Type: "hero", Universe: "Earth-616"},
+		{FullName: "Spider-Man", Identity: "Miles Morales", Type: "hero", Universe: "Earth-1610"},
+		{FullName: "Superior Spider-Man", Identity: "Otto Octavius", Type: "anti-hero", Universe: "Earth-616"},
+		{FullName: "Venom", Identity: "Eddie Brock", Type: "anti-hero", Universe: "Earth-616"},
+	} {
+		stub.documents[strconv.Itoa(i+1)] = character
+	}
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	// Small pages make the listing go through more than one of them
+	defaultPageSize := listPageSize
+	listPageSize = 2
+	defer func() { listPageSize = defaultPageSize }()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	listServer, ok := server.(tfprotov6.ProviderServerWithListResource)
+	if !ok {
+		t.Fatal("expected the provider server to serve list resources")
+	}
+
+	typeName := providerTypeName + characterResourceTypeName
+	listSchema := schemaResponse.ListResourceSchemas[typeName]
+	if listSchema == nil {
+		t.Fatalf("expected the %s list resource to be registered", typeName)
+	}
+
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identitySchema := identitySchemas.IdentitySchemas[typeName]
+	if identitySchema == nil {
+		t.Fatalf("expected the %s resource to have an identity schema", typeName)
+	}
+
+	testCases := map[string]struct {
+		filters     map[string]tftypes.Value
+		limit       int64
+		expectedIDs []string
+	}{
+		"all": {
+			limit:       10,
+			expectedIDs: []string{"1", "2", "3", "4"},
+		},
+		"limited": {
+			limit:       3,
+			expectedIDs: []string{"1", "2", "3"},
+		},
+		"fullname": {
+			filters: map[string]tftypes.Value{
+				fullNameField: tftypes.NewValue(tftypes.String, "spider-man"),
+			},
+			limit:       10,
+			expectedIDs: []string{"1", "2"},
+		},
+		"type and universe": {
+			filters: map[string]tftypes.Value{
+				typeField:     tftypes.NewValue(tftypes.String, "hero"),
+				universeField: tftypes.NewValue(tftypes.String, "earth-616"),
+			},
+			limit:       10,
+			expectedIDs: []string{"1"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			listResponse, err := listServer.ListResource(ctx, &tfprotov6.ListResourceRequest{
+				TypeName:        typeName,
+				Config:          testDynamicValue(t, listSchema, testCase.filters),
+				IncludeResource: true,
+				Limit:           testCase.limit,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			listedIDs := []string{}
+			for result := range listResponse.Results {
+				for _, diagnostic := range result.Diagnostics {
+					t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+
+				identityValue, err := result.Identity.IdentityData.Unmarshal(identitySchema.ValueType())
+				if err != nil {
+					t.Fatal(err)
+				}
+				identity := map[string]tftypes.Value{}
+				identityValue.As(&identity)
+				var cluster, index, documentID string
+				identity[clusterField].As(&cluster)
+				identity[indexField].As(&index)
+				identity[documentIDField].As(&documentID)
+				if cluster != "opensearch-cluster" || index != backendIndex {
+					t.Errorf("expected the identity to hold the cluster and index, got '%s' and '%s'", cluster, index)
+				}
+
+				resourceValue, err := result.Resource.Unmarshal(schemaResponse.ResourceSchemas[typeName].ValueType())
+				if err != nil {
+					t.Fatal(err)
+				}
+				resource := map[string]tftypes.Value{}
+				resourceValue.As(&resource)
+				var id, fullName string
+				resource[idField].As(&id)
+				resource[fullNameField].As(&fullName)
+				if id != documentID || result.DisplayName != fullName || fullName != stub.documents[id].FullName {
+					t.Errorf("expected the result to describe the character '%s', got '%s' named '%s'", documentID, id, result.DisplayName)
+				}
+
+				listedIDs = append(listedIDs, documentID)
+			}
+
+			if len(listedIDs) != len(testCase.expectedIDs) {
+				t.Fatalf("expected the characters %v, got %v", testCase.expectedIDs, listedIDs)
+			}
+			for i := range listedIDs {
+				if listedIDs[i] != testCase.expectedIDs[i] {
+					t.Errorf("expected the characters %v, got %v", testCase.expectedIDs, listedIDs)
+					break
+				}
+			}
+
+			// The scroll is cleared even when the limit stops the listing early
+			if len(stub.scrolls) != 0 {
+				t.Errorf("expected the scroll to be cleared, got %v", stub.scrolls)
+			}
+
+		})
+	}
+
+}
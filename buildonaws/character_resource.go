@@ -1,29 +1,34 @@
 package buildonaws
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/opensearch-project/opensearch-go/v2"
-	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 )
 
 var (
-	_ resource.Resource                = &characterResource{}
-	_ resource.ResourceWithConfigure   = &characterResource{}
-	_ resource.ResourceWithImportState = &characterResource{}
+	_ resource.Resource                     = &characterResource{}
+	_ resource.ResourceWithConfigure        = &characterResource{}
+	_ resource.ResourceWithConfigValidators = &characterResource{}
+	_ resource.ResourceWithIdentity         = &characterResource{}
+	_ resource.ResourceWithImportState      = &characterResource{}
+	_ resource.ResourceWithModifyPlan       = &characterResource{}
+	_ resource.ResourceWithUpgradeState     = &characterResource{}
 )
 
 func NewCharacterResource() resource.Resource {
@@ -31,7 +36,11 @@ func NewCharacterResource() resource.Resource {
 }
 
 type characterResource struct {
-	backendClient *opensearch.Client
+	backend         *backend
+	characterStore  characterStore
+	fullNamePattern *regexp.Regexp
+	maxTextLength   int
+	uniqueFullNames bool
 }
 
 func (r *characterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -40,6 +49,7 @@ func (r *characterResource) Metadata(_ context.Context, req resource.MetadataReq
 
 func (c *characterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: characterResourceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			idField: schema.StringAttribute{
 				Description: idFieldDesc,
@@ -52,10 +62,17 @@ func (c *characterResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: fullNameFieldDesc,
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
 			},
 			identityField: schema.StringAttribute{
 				Description: identityFieldDesc,
 				Required:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
 			},
 			knownasField: schema.StringAttribute{
 				Description: knowasFieldDesc,
@@ -66,12 +83,62 @@ func (c *characterResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: typeFieldDesc,
 				Optional:    true,
 				Computed:    true,
-				Validators: []validator.String{
-					stringvalidator.OneOf(characterTypes...),
+			},
+			universeField: schema.StringAttribute{
+				Description: universeFieldDesc,
+				Optional:    true,
+				Computed:    true,
+			},
+			tagsField: schema.SetAttribute{
+				Description: tagsFieldDesc,
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			createdAtField: schema.StringAttribute{
+				Description: createdAtFieldDesc,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			lastUpdatedField: schema.StringAttribute{
-				Computed: true,
+				Description: lastUpdatedFieldDesc,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			timeoutsField: timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: createTimeoutDesc,
+				ReadDescription:   readTimeoutDesc,
+				UpdateDescription: updateTimeoutDesc,
+				DeleteDescription: deleteTimeoutDesc,
+			}),
+		},
+	}
+}
+
+// IdentitySchema describes characters by where they are stored, so the
+// same document identifier in different clusters is not mistaken for the
+// same character.
+func (c *characterResource) IdentitySchema(_ context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			clusterField: identityschema.StringAttribute{
+				Description:       identityClusterFieldDesc,
+				OptionalForImport: true,
+			},
+			indexField: identityschema.StringAttribute{
+				Description:       identityIndexFieldDesc,
+				OptionalForImport: true,
+			},
+			documentIDField: identityschema.StringAttribute{
+				Description:       identityDocumentIDFieldDesc,
+				RequiredForImport: true,
 			},
 		},
 	}
@@ -85,15 +152,81 @@ func (c *characterResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	c.backendClient = req.ProviderData.(*opensearch.Client)
+	backend := req.ProviderData.(*backend)
+	c.backend = backend
+	c.characterStore = backend.characterStore
+	c.fullNamePattern = backend.fullNamePattern
+	c.maxTextLength = backend.maxTextLength
+	c.uniqueFullNames = backend.uniqueFullNames
 
 }
 
+func (c *characterResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// A fullname equal to the identity would give the secret away
+		distinctValuesValidator{
+			first:  path.Root(fullNameField),
+			second: path.Root(identityField),
+		},
+	}
+}
+
 func (c *characterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root(idField), req, resp)
+
+	if req.ID != "" {
+		resource.ImportStatePassthroughID(ctx, path.Root(idField), req, resp)
+		return
+	}
+
+	// Imported by an identity, where only the document identifier is required
+	var characterIdentity CharacterIdentityModel
+	diags := req.Identity.Get(ctx, &characterIdentity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !characterIdentity.Index.IsNull() && characterIdentity.Index.ValueString() != backendIndex {
+		resp.Diagnostics.AddError(
+			"Invalid import identity",
+			"The resource stores documents in the index '"+backendIndex+"', not '"+characterIdentity.Index.ValueString()+"'.",
+		)
+		return
+	}
+
+	identity := c.identity(ctx, characterIdentity.DocumentID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !characterIdentity.Cluster.IsNull() && !characterIdentity.Cluster.Equal(identity.Cluster) {
+		connectedTo := "is not connected to a cluster"
+		if !identity.Cluster.IsNull() {
+			connectedTo = "is connected to the cluster '" + identity.Cluster.ValueString() + "'"
+		}
+		resp.Diagnostics.AddError(
+			"Invalid import identity",
+			"The character is stored in the cluster '"+characterIdentity.Cluster.ValueString()+"', but the provider "+connectedTo+".",
+		)
+		return
+	}
+
+	diags = resp.State.SetAttribute(ctx, path.Root(idField), identity.DocumentID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Identity.Set(ctx, identity)
+	resp.Diagnostics.Append(diags...)
+
 }
 
-func (c *characterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (c *characterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	if req.Plan.Raw.IsNull() || c.characterStore == nil {
+		return
+	}
 
 	var characterPlan CharacterResourceModel
 	diags := req.Plan.Get(ctx, &characterPlan)
@@ -102,50 +235,209 @@ func (c *characterResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	comicCharacter := &ComicCharacter{
-		FullName: characterPlan.FullName.ValueString(),
-		Identity: characterPlan.Identity.ValueString(),
-		KnownAs:  characterPlan.KnownAs.ValueString(),
-		Type:     characterPlan.Type.ValueString(),
+	// Universes left out of the configuration are planned as unknown,
+	// although characters are stored without one
+	var universe types.String
+	diags = req.Config.GetAttribute(ctx, path.Root(universeField), &universe)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	bodyContent, _ := json.Marshal(comicCharacter)
-	bodyReader := bytes.NewReader(bodyContent)
-	indexRequest := opensearchapi.IndexRequest{
-		Index: backendIndex,
-		Body:  bodyReader,
+
+	c.validateTextLengths(characterPlan, &resp.Diagnostics)
+	c.validateType(ctx, characterPlan.Type, true, &resp.Diagnostics)
+	c.validateFullName(ctx, characterPlan, universe, &resp.Diagnostics)
+
+}
+
+// validateType checks custom types against the backend. At plan time a type
+// missing from it may be created by a buildonaws_character_type in the same
+// apply, so it is only reported as a warning and checked again at apply time.
+func (c *characterResource) validateType(ctx context.Context, characterType types.String, planning bool, diags *diag.Diagnostics) {
+
+	if characterType.IsNull() || characterType.IsUnknown() || characterType.ValueString() == "" {
+		return
 	}
 
-	indexResponse, err := indexRequest.Do(ctx, c.backendClient)
+	if isBuiltInCharacterType(characterType.ValueString()) {
+		return
+	}
+
+	exists, err := c.characterStore.CharacterTypeExists(ctx, characterType.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while creating character",
+		diags.AddError(
+			"Error while validating character type",
 			"Reason: "+err.Error(),
 		)
 		return
 	}
 
-	defer indexResponse.Body.Close()
-	bodyContent, err = io.ReadAll(indexResponse.Body)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while reading response",
-			"Reason: "+err.Error(),
+	if exists {
+		return
+	}
+
+	detail := "The type '" + characterType.ValueString() + "' is neither a built-in type ('" +
+		strings.Join(characterTypes, ",") + "') nor registered with the buildonaws_character_type resource."
+	if planning {
+		diags.AddAttributeWarning(
+			path.Root(typeField),
+			"Unregistered character type",
+			detail+" It is checked again when applying, once the character types of the same apply are created.",
+		)
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root(typeField),
+		"Invalid character type",
+		detail,
+	)
+
+}
+
+// validateTextLengths applies the length limit set in the provider
+// configuration, counting characters rather than bytes.
+func (c *characterResource) validateTextLengths(characterPlan CharacterResourceModel, diags *diag.Diagnostics) {
+
+	if c.maxTextLength <= 0 {
+		return
+	}
+
+	for _, attribute := range []struct {
+		field string
+		value types.String
+	}{
+		{fullNameField, characterPlan.FullName},
+		{identityField, characterPlan.Identity},
+		{knownasField, characterPlan.KnownAs},
+	} {
+		field, value := attribute.field, attribute.value
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		length := utf8.RuneCountInString(value.ValueString())
+		if length > c.maxTextLength {
+			diags.AddAttributeError(
+				path.Root(field),
+				"Invalid Attribute Value Length",
+				"Attribute "+field+" string length must be at most "+strconv.Itoa(c.maxTextLength)+
+					", as set in the provider configuration, got: "+strconv.Itoa(length),
+			)
+		}
+	}
+
+}
+
+// validateFullName applies the rules set in the provider configuration,
+// which schema validators cannot reach as they run before it is configured.
+func (c *characterResource) validateFullName(ctx context.Context, characterPlan CharacterResourceModel, universe types.String, diags *diag.Diagnostics) {
+
+	fullName := characterPlan.FullName
+	if fullName.IsNull() || fullName.IsUnknown() || fullName.ValueString() == "" {
+		return
+	}
+
+	if c.fullNamePattern != nil && !c.fullNamePattern.MatchString(fullName.ValueString()) {
+		diags.AddAttributeError(
+			path.Root(fullNameField),
+			"Invalid character fullname",
+			"The fullname '"+fullName.ValueString()+"' does not match the pattern '"+
+				c.fullNamePattern.String()+"' set in the provider configuration.",
 		)
 		return
 	}
 
-	backendResponse := &BackendResponse{}
-	err = json.Unmarshal(bodyContent, backendResponse)
+	if !c.uniqueFullNames || universe.IsUnknown() {
+		return
+	}
+
+	characters, err := c.characterStore.FindCharactersByFullName(ctx, fullName.ValueString(), universe.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while unmarshalling response",
+		diags.AddError(
+			"Error while validating character fullname",
 			"Reason: "+err.Error(),
 		)
 		return
 	}
 
-	characterPlan.ID = types.StringValue(backendResponse.ID)
-	characterPlan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	// The character being updated is found as well, under its own identifier
+	for _, character := range characters {
+		if character.ID != characterPlan.ID.ValueString() {
+			diags.AddAttributeError(
+				path.Root(fullNameField),
+				"Duplicate character fullname",
+				"The character '"+character.ID+"' already has the fullname '"+fullName.ValueString()+
+					"' in the universe '"+universe.ValueString()+"'.",
+			)
+			return
+		}
+	}
+
+}
+
+func (c *characterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var characterPlan CharacterResourceModel
+	diags := req.Plan.Get(ctx, &characterPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := characterPlan.Timeouts.Create(ctx, createTimeoutDefault)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tags := []string{}
+	if !characterPlan.Tags.IsUnknown() {
+		diags = characterPlan.Tags.ElementsAs(ctx, &tags, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Timestamps are kept in the document, so every client agrees on them
+	now := time.Now().UTC().Format(time.RFC3339)
+	comicCharacter := &ComicCharacter{
+		FullName:  characterPlan.FullName.ValueString(),
+		Identity:  characterPlan.Identity.ValueString(),
+		KnownAs:   characterPlan.KnownAs.ValueString(),
+		Type:      characterPlan.Type.ValueString(),
+		Universe:  characterPlan.Universe.ValueString(),
+		Tags:      tags,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	c.validateType(ctx, characterPlan.Type, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Identified before writing, so a failed cluster lookup cannot leave
+	// a stored character out of the state
+	identity := c.identity(ctx, "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documentID, err := c.characterStore.CreateCharacter(ctx, comicCharacter)
+	if err != nil {
+		addOperationError(ctx, &resp.Diagnostics, "Error while creating character", "create", createTimeout, err)
+		return
+	}
+
+	characterPlan.ID = types.StringValue(documentID)
+	characterPlan.Universe = types.StringValue(comicCharacter.Universe)
+	characterPlan.Tags, diags = types.SetValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	characterPlan.CreatedAt = types.StringValue(comicCharacter.CreatedAt)
+	characterPlan.LastUpdated = types.StringValue(comicCharacter.UpdatedAt)
 
 	diags = resp.State.Set(ctx, characterPlan)
 	resp.Diagnostics.Append(diags...)
@@ -153,6 +445,13 @@ func (c *characterResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	identity.DocumentID = types.StringValue(documentID)
+	diags = resp.Identity.Set(ctx, identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 }
 
 func (c *characterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -164,48 +463,42 @@ func (c *characterResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := characterState.Timeouts.Read(ctx, readTimeoutDefault)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	documentID := characterState.ID.ValueString()
 
-	getRequest := opensearchapi.GetRequest{
-		Index:      backendIndex,
-		DocumentID: documentID,
+	character, err := c.characterStore.ReadCharacter(ctx, documentID)
+	if err == errCharacterNotFound {
+		resp.State.RemoveResource(ctx)
+		return
 	}
-
-	getResponse, err := getRequest.Do(ctx, c.backendClient)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while reading character",
-			"Reason: "+err.Error(),
-		)
+		addOperationError(ctx, &resp.Diagnostics, "Error while reading character", "read", readTimeout, err)
 		return
 	}
 
-	defer getResponse.Body.Close()
-	bodyContent, err := io.ReadAll(getResponse.Body)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while reading response",
-			"Reason: "+err.Error(),
-		)
+	diags = characterResourceModelOf(ctx, &characterState, character)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &characterState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	backendResponse := &BackendResponse{}
-	err = json.Unmarshal(bodyContent, backendResponse)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while unmarshalling response",
-			"Reason: "+err.Error(),
-		)
+	identity := c.identity(ctx, documentID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	characterState.FullName = types.StringValue(backendResponse.Source.FullName)
-	characterState.Identity = types.StringValue(backendResponse.Source.Identity)
-	characterState.KnownAs = types.StringValue(backendResponse.Source.KnownAs)
-	characterState.Type = types.StringValue(backendResponse.Source.Type)
-
-	diags = resp.State.Set(ctx, &characterState)
+	diags = resp.Identity.Set(ctx, identity)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -222,44 +515,57 @@ func (c *characterResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := characterPlan.Timeouts.Update(ctx, updateTimeoutDefault)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	documentID := characterPlan.ID.ValueString()
 
-	updateBody := &struct {
-		Doc ComicCharacter `json:"doc,omitempty"`
-	}{
-		Doc: ComicCharacter{
-			FullName: characterPlan.FullName.ValueString(),
-			Identity: characterPlan.Identity.ValueString(),
-			KnownAs:  characterPlan.KnownAs.ValueString(),
-			Type:     characterPlan.Type.ValueString(),
-		},
+	tags := []string{}
+	if !characterPlan.Tags.IsUnknown() {
+		diags = characterPlan.Tags.ElementsAs(ctx, &tags, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	bodyContent, err := json.Marshal(updateBody)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while marshalling request",
-			"Reason: "+err.Error(),
-		)
+	// The creation timestamp is left out, so the stored one is kept
+	comicCharacter := &ComicCharacter{
+		FullName:  characterPlan.FullName.ValueString(),
+		Identity:  characterPlan.Identity.ValueString(),
+		KnownAs:   characterPlan.KnownAs.ValueString(),
+		Type:      characterPlan.Type.ValueString(),
+		Universe:  characterPlan.Universe.ValueString(),
+		Tags:      tags,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	c.validateType(ctx, characterPlan.Type, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	bodyReader := bytes.NewReader(bodyContent)
-	updateRequest := opensearchapi.UpdateRequest{
-		Index:      backendIndex,
-		DocumentID: documentID,
-		Body:       bodyReader,
+
+	identity := c.identity(ctx, documentID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	_, err = updateRequest.Do(ctx, c.backendClient)
+	err := c.characterStore.UpdateCharacter(ctx, documentID, comicCharacter)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating character",
-			"Reason: "+err.Error(),
-		)
+		addOperationError(ctx, &resp.Diagnostics, "Error while updating character", "update", updateTimeout, err)
 		return
 	}
 
-	characterPlan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	characterPlan.Universe = types.StringValue(comicCharacter.Universe)
+	characterPlan.Tags, diags = types.SetValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	characterPlan.LastUpdated = types.StringValue(comicCharacter.UpdatedAt)
 
 	diags = resp.State.Set(ctx, characterPlan)
 	resp.Diagnostics.Append(diags...)
@@ -267,6 +573,12 @@ func (c *characterResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	diags = resp.Identity.Set(ctx, identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 }
 
 func (c *characterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -278,18 +590,77 @@ func (c *characterResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := characterState.Timeouts.Delete(ctx, deleteTimeoutDefault)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	documentID := characterState.ID.ValueString()
-	deleteRequest := opensearchapi.DeleteRequest{
-		Index:      backendIndex,
-		DocumentID: documentID,
+	err := c.characterStore.DeleteCharacter(ctx, documentID)
+	if err != nil {
+		addOperationError(ctx, &resp.Diagnostics, "Error while deleting character", "delete", deleteTimeout, err)
+		return
 	}
-	_, err := deleteRequest.Do(ctx, c.backendClient)
+
+}
+
+// characterResourceModelOf fills the model with the character as stored in
+// the backend, leaving the timestamps as they are for characters stored by
+// older versions of the provider, which have none.
+func characterResourceModelOf(ctx context.Context, characterModel *CharacterResourceModel, character *ComicCharacter) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	characterModel.ID = types.StringValue(character.ID)
+	characterModel.FullName = types.StringValue(character.FullName)
+	characterModel.Identity = types.StringValue(character.Identity)
+	characterModel.KnownAs = types.StringValue(character.KnownAs)
+	characterModel.Type = types.StringValue(character.Type)
+	characterModel.Universe = types.StringValue(character.Universe)
+	characterModel.Tags, diags = types.SetValueFrom(ctx, types.StringType, nonNilTags(character.Tags))
+
+	if character.CreatedAt != "" {
+		characterModel.CreatedAt = types.StringValue(character.CreatedAt)
+	}
+	if character.UpdatedAt != "" {
+		characterModel.LastUpdated = types.StringValue(character.UpdatedAt)
+	}
+
+	return diags
+
+}
+
+func (c *characterResource) identity(ctx context.Context, documentID string, diags *diag.Diagnostics) CharacterIdentityModel {
+	return characterIdentity(ctx, c.backend, documentID, diags)
+}
+
+// characterIdentity tells where the character is stored. The cluster
+// name is asked to the backend once, when it was not while configuring.
+func characterIdentity(ctx context.Context, backend *backend, documentID string, diags *diag.Diagnostics) CharacterIdentityModel {
+
+	identity := CharacterIdentityModel{
+		Cluster:    types.StringNull(),
+		Index:      types.StringValue(backendIndex),
+		DocumentID: types.StringValue(documentID),
+	}
+
+	clusterName, err := backend.clusterName(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while deleting character",
-			"Reason: "+err.Error(),
+		diags.AddError(
+			"Error while identifying the cluster",
+			"The name of the cluster where the character is stored could not be read. Reason: "+err.Error(),
 		)
-		return
+		return identity
 	}
 
+	if clusterName != "" {
+		identity.Cluster = types.StringValue(clusterName)
+	}
+
+	return identity
+
 }
@@ -2,9 +2,15 @@ package buildonaws
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -68,14 +74,14 @@ func TestAccCharacterResource(t *testing.T) {
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet(resourceName, idField),
 					resource.TestCheckResourceAttrSet(resourceName, lastUpdatedField),
+					resource.TestCheckResourceAttrPair(resourceName, createdAtField, resourceName, lastUpdatedField),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{lastUpdatedField},
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
 			},
 			// Update and Read testing
 			{
@@ -88,6 +94,7 @@ func TestAccCharacterResource(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, typeField, character.Type),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet(resourceName, idField),
+					resource.TestCheckResourceAttrSet(resourceName, createdAtField),
 					resource.TestCheckResourceAttrSet(resourceName, lastUpdatedField),
 				),
 			},
@@ -96,3 +103,423 @@ func TestAccCharacterResource(t *testing.T) {
 	})
 
 }
+
+func TestAccCharacterResourceTimeout(t *testing.T) {
+
+	// The backend never answers, until the provider gives up on the request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer backend.Close()
+
+	terraformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+		connect_mode = "skip"
+	}
+
+	resource "buildonaws_character" "daredevil" {
+		identity = "Matt Murdock"
+
+		timeouts {
+			create = "1s"
+		}
+	}`
+
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${backend_address}", backend.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      terraformConfig,
+				ExpectError: regexp.MustCompile("Timeout while running the create operation"),
+			},
+		},
+	})
+
+}
+
+func TestAccCharacterResourceValidation(t *testing.T) {
+
+	providerConfig := `
+	provider "buildonaws" {
+		backend_type = "file"
+		file_path = "${file_path}"
+		fullname_pattern = "^[A-Z]"
+		unique_fullnames = true
+	}
+	`
+
+	daredevilConfig := `
+	resource "buildonaws_character" "daredevil" {
+		fullname = "Daredevil"
+		identity = "Matt Murdock"
+		knownas = "${knownas}"
+		universe = "Earth-616"
+	}
+	`
+
+	filePath := filepath.ToSlash(filepath.Join(t.TempDir(), "characters"))
+	providerConfig = strings.ReplaceAll(providerConfig, "${file_path}", filePath)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+				resource "buildonaws_character" "invalid" {
+					fullname = "Matt Murdock"
+					identity = "matt murdock "
+				}`,
+				ExpectError: regexp.MustCompile("Identical attribute values"),
+			},
+			{
+				Config: providerConfig + `
+				resource "buildonaws_character" "invalid" {
+					identity = "   "
+				}`,
+				ExpectError: regexp.MustCompile("Blank value"),
+			},
+			{
+				Config: providerConfig + `
+				resource "buildonaws_character" "invalid" {
+					fullname = "daredevil"
+					identity = "Matt Murdock"
+				}`,
+				ExpectError: regexp.MustCompile("Invalid character fullname"),
+			},
+			{
+				Config: providerConfig + strings.ReplaceAll(daredevilConfig, "${knownas}", "The man without fear"),
+			},
+			// The character itself is not taken for a duplicate when updated
+			{
+				Config: providerConfig + strings.ReplaceAll(daredevilConfig, "${knownas}", "The devil of hell's kitchen"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("buildonaws_character.daredevil", knownasField, "The devil of hell's kitchen"),
+				),
+			},
+			{
+				Config: providerConfig + strings.ReplaceAll(daredevilConfig, "${knownas}", "The devil of hell's kitchen") + `
+				resource "buildonaws_character" "impostor" {
+					fullname = "Daredevil"
+					identity = "Mike Murdock"
+					universe = "Earth-616"
+				}`,
+				ExpectError: regexp.MustCompile("Duplicate character fullname"),
+			},
+		},
+	})
+
+}
+
+func TestCharacterResourceImportByIdentity(t *testing.T) {
+
+	ctx := context.Background()
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	server, _ := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + characterResourceTypeName
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityType := identitySchemas.IdentitySchemas[typeName].ValueType()
+
+	testCases := map[string]struct {
+		cluster interface{}
+		index   interface{}
+		err     string
+	}{
+		"document only":  {},
+		"same cluster":   {cluster: "opensearch-cluster", index: backendIndex},
+		"other index":    {index: characterTypeIndex, err: "not '" + characterTypeIndex + "'"},
+		"other cluster":  {cluster: "production", err: "connected to the cluster 'opensearch-cluster'"},
+		"unknown target": {cluster: "production", index: "unknown", err: "not 'unknown'"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			identityValue := tftypes.NewValue(identityType, map[string]tftypes.Value{
+				clusterField:    tftypes.NewValue(tftypes.String, testCase.cluster),
+				indexField:      tftypes.NewValue(tftypes.String, testCase.index),
+				documentIDField: tftypes.NewValue(tftypes.String, "42"),
+			})
+			identityData, err := tfprotov6.NewDynamicValue(identityType, identityValue)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			importResponse, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+				TypeName: typeName,
+				Identity: &tfprotov6.ResourceIdentityData{IdentityData: &identityData},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.err != "" {
+				if len(importResponse.Diagnostics) == 0 || !strings.Contains(importResponse.Diagnostics[0].Detail, testCase.err) {
+					t.Errorf("expected an error containing '%s', got %+v", testCase.err, importResponse.Diagnostics)
+				}
+				return
+			}
+			for _, diagnostic := range importResponse.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+
+			importedIdentity, err := importResponse.ImportedResources[0].Identity.IdentityData.Unmarshal(identityType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedIdentity := tftypes.NewValue(identityType, map[string]tftypes.Value{
+				clusterField:    tftypes.NewValue(tftypes.String, "opensearch-cluster"),
+				indexField:      tftypes.NewValue(tftypes.String, backendIndex),
+				documentIDField: tftypes.NewValue(tftypes.String, "42"),
+			})
+			if !importedIdentity.Equal(expectedIdentity) {
+				t.Errorf("expected the identity %s, got %s", expectedIdentity, importedIdentity)
+			}
+
+		})
+	}
+
+}
+
+func TestCharacterResourceCustomType(t *testing.T) {
+
+	ctx := context.Background()
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	// Looking up character types fails on this backend
+	failingBackend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"type":"security_exception","reason":"action unauthorized"},"status":403}`))
+	}))
+	defer failingBackend.Close()
+
+	typeName := providerTypeName + characterResourceTypeName
+	character := func(characterType string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			fullNameField: tftypes.NewValue(tftypes.String, "Robin"),
+			identityField: tftypes.NewValue(tftypes.String, "Dick Grayson"),
+			typeField:     tftypes.NewValue(tftypes.String, characterType),
+		}
+	}
+
+	testCases := map[string]struct {
+		address       string
+		characterType string
+		planWarning   string
+		planError     string
+		applyError    string
+		createdCount  int
+	}{
+		"registered type": {
+			address:       backend.URL,
+			characterType: "sidekick",
+			createdCount:  1,
+		},
+		"type created in the same apply": {
+			address:       backend.URL,
+			characterType: "henchman",
+			planWarning:   "Unregistered character type",
+			applyError:    "Invalid character type",
+		},
+		"backend error": {
+			address:       failingBackend.URL,
+			characterType: "henchman",
+			planError:     "403 Forbidden",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			stub.documents = map[string]*ComicCharacter{}
+
+			server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+				backendAddressField: tftypes.NewValue(tftypes.String, testCase.address),
+				connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+			})
+			resourceSchema := schemaResponse.ResourceSchemas[typeName]
+
+			config := testDynamicValue(t, resourceSchema, character(testCase.characterType))
+			priorState, err := tfprotov6.NewDynamicValue(resourceSchema.ValueType(), tftypes.NewValue(resourceSchema.ValueType(), nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			planResponse, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         typeName,
+				PriorState:       &priorState,
+				ProposedNewState: config,
+				Config:           config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var summaries, details []string
+			for _, diagnostic := range planResponse.Diagnostics {
+				summaries = append(summaries, diagnostic.Summary)
+				details = append(details, diagnostic.Detail)
+			}
+			if testCase.planError != "" {
+				if len(details) == 0 || !strings.Contains(details[0], testCase.planError) {
+					t.Errorf("plan: expected an error containing '%s', got %v", testCase.planError, details)
+				}
+				return
+			}
+			if testCase.planWarning != "" && (len(summaries) != 1 || summaries[0] != testCase.planWarning ||
+				planResponse.Diagnostics[0].Severity != tfprotov6.DiagnosticSeverityWarning) {
+				t.Errorf("plan: expected the warning '%s', got %v", testCase.planWarning, summaries)
+			}
+			if testCase.planWarning == "" && len(summaries) != 0 {
+				t.Errorf("plan: unexpected diagnostics %v", summaries)
+			}
+
+			diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName, nil, character(testCase.characterType))
+			if testCase.applyError != "" {
+				if len(diagnostics) == 0 || diagnostics[0].Summary != testCase.applyError {
+					t.Errorf("apply: expected the error '%s', got %+v", testCase.applyError, diagnostics)
+				}
+			} else {
+				for _, diagnostic := range diagnostics {
+					t.Errorf("apply: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+			}
+			if len(stub.documents) != testCase.createdCount {
+				t.Errorf("apply: expected %d characters to be created, got %d", testCase.createdCount, len(stub.documents))
+			}
+
+		})
+	}
+
+}
+
+func TestCharacterResourceMaxTextLength(t *testing.T) {
+
+	ctx := context.Background()
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	typeName := providerTypeName + characterResourceTypeName
+
+	testCases := map[string]struct {
+		maxTextLength interface{}
+		fullName      string
+		err           string
+	}{
+		"default limit":           {fullName: strings.Repeat("é", characterTextMaxLength)},
+		"default limit exceeded":  {fullName: strings.Repeat("é", characterTextMaxLength+1), err: "at most 256"},
+		"configured limit":        {maxTextLength: 8, fullName: "Daredevil", err: "at most 8"},
+		"configured larger limit": {maxTextLength: 512, fullName: strings.Repeat("a", 300)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			var maxTextLength tftypes.Value
+			if testCase.maxTextLength != nil {
+				maxTextLength = tftypes.NewValue(tftypes.Number, testCase.maxTextLength)
+			} else {
+				maxTextLength = tftypes.NewValue(tftypes.Number, nil)
+			}
+			server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+				backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+				connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+				maxTextLengthField:  maxTextLength,
+			})
+			resourceSchema := schemaResponse.ResourceSchemas[typeName]
+
+			config := testDynamicValue(t, resourceSchema, map[string]tftypes.Value{
+				fullNameField: tftypes.NewValue(tftypes.String, testCase.fullName),
+				identityField: tftypes.NewValue(tftypes.String, "Matt Murdock"),
+			})
+			priorState, err := tfprotov6.NewDynamicValue(resourceSchema.ValueType(), tftypes.NewValue(resourceSchema.ValueType(), nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			planResponse, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         typeName,
+				PriorState:       &priorState,
+				ProposedNewState: config,
+				Config:           config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.err != "" {
+				if len(planResponse.Diagnostics) == 0 || !strings.Contains(planResponse.Diagnostics[0].Detail, testCase.err) {
+					t.Errorf("expected an error containing '%s', got %+v", testCase.err, planResponse.Diagnostics)
+				}
+				return
+			}
+			for _, diagnostic := range planResponse.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+
+		})
+	}
+
+}
+
+func TestCharacterResourceClusterLookupFailure(t *testing.T) {
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	// The cluster name cannot be read from a backend of another distribution
+	stub.infoResult = elasticsearch8InfoResponse
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeLazy),
+	})
+
+	typeName := providerTypeName + characterResourceTypeName
+	character := func(id interface{}, knownAs string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			idField:       tftypes.NewValue(tftypes.String, id),
+			fullNameField: tftypes.NewValue(tftypes.String, "Daredevil"),
+			identityField: tftypes.NewValue(tftypes.String, "Matt Murdock"),
+			knownasField:  tftypes.NewValue(tftypes.String, knownAs),
+			typeField:     tftypes.NewValue(tftypes.String, characterTypes[0]),
+		}
+	}
+
+	diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName, nil, character(nil, "The man without fear"))
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while identifying the cluster" {
+		t.Errorf("create: expected the cluster lookup to fail, got %+v", diagnostics)
+	}
+	if len(stub.documents) != 0 {
+		t.Errorf("create: expected no character to be stored, got %d", len(stub.documents))
+	}
+
+	stub.documents["1"] = &ComicCharacter{FullName: "Daredevil", Identity: "Matt Murdock", KnownAs: "The man without fear"}
+	diagnostics = applyTestResourceChange(t, server, schemaResponse, typeName,
+		character("1", "The man without fear"), character("1", "The devil of hell's kitchen"))
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while identifying the cluster" {
+		t.Errorf("update: expected the cluster lookup to fail, got %+v", diagnostics)
+	}
+	if stub.documents["1"].KnownAs != "The man without fear" {
+		t.Errorf("update: expected the character to be left untouched, got '%s'", stub.documents["1"].KnownAs)
+	}
+
+}
@@ -0,0 +1,117 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// characterResourceSchemaVersion must be increased, along with a new state
+// upgrader, whenever a change to the schema breaks the existing state.
+const characterResourceSchemaVersion = 1
+
+// characterResourceStateV0 is the state written before the schema was
+// versioned. Attributes were added over time without changing the version,
+// so any of them may be missing, and last_updated may use the RFC850 format
+// of the client that wrote it.
+type characterResourceStateV0 struct {
+	ID          *string            `json:"id"`
+	FullName    *string            `json:"fullname"`
+	Identity    *string            `json:"identity"`
+	KnownAs     *string            `json:"knownas"`
+	Type        *string            `json:"type"`
+	Universe    *string            `json:"universe"`
+	Tags        []string           `json:"tags"`
+	CreatedAt   *string            `json:"created_at"`
+	LastUpdated *string            `json:"last_updated"`
+	Timeouts    map[string]*string `json:"timeouts"`
+}
+
+func (c *characterResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			// No prior schema, as the shape of version 0 varies
+			StateUpgrader: upgradeCharacterStateFromV0,
+		},
+	}
+}
+
+func upgradeCharacterStateFromV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+
+	characterStateV0 := &characterResourceStateV0{}
+	err := json.Unmarshal(req.RawState.JSON, characterStateV0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while upgrading character state",
+			"The state written by a prior version of the provider could not be read. Reason: "+err.Error(),
+		)
+		return
+	}
+
+	characterState := CharacterResourceModel{
+		ID:          types.StringPointerValue(characterStateV0.ID),
+		FullName:    types.StringPointerValue(characterStateV0.FullName),
+		Identity:    types.StringPointerValue(characterStateV0.Identity),
+		KnownAs:     types.StringPointerValue(characterStateV0.KnownAs),
+		Type:        types.StringPointerValue(characterStateV0.Type),
+		Universe:    types.StringPointerValue(characterStateV0.Universe),
+		Tags:        types.SetNull(types.StringType),
+		CreatedAt:   types.StringPointerValue(characterStateV0.CreatedAt),
+		LastUpdated: types.StringPointerValue(upgradeTimestamp(characterStateV0.LastUpdated)),
+		Timeouts:    upgradeTimeouts(characterStateV0.Timeouts),
+	}
+
+	if characterStateV0.Tags != nil {
+		tags, diags := types.SetValueFrom(ctx, types.StringType, characterStateV0.Tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		characterState.Tags = tags
+	}
+
+	diags := resp.State.Set(ctx, characterState)
+	resp.Diagnostics.Append(diags...)
+
+}
+
+// upgradeTimestamp converts timestamps written with the RFC850 format into
+// RFC3339 in UTC, leaving any other value untouched. Read replaces them
+// with the ones stored in the backend anyway, when there are any.
+func upgradeTimestamp(timestamp *string) *string {
+
+	if timestamp == nil {
+		return nil
+	}
+
+	parsedTime, err := time.Parse(time.RFC850, *timestamp)
+	if err != nil {
+		return timestamp
+	}
+
+	upgradedTimestamp := parsedTime.UTC().Format(time.RFC3339)
+	return &upgradedTimestamp
+
+}
+
+func upgradeTimeouts(timeoutsV0 map[string]*string) timeouts.Value {
+
+	attributeTypes := map[string]attr.Type{}
+	attributeValues := map[string]attr.Value{}
+	for _, operation := range []string{"create", "read", "update", "delete"} {
+		attributeTypes[operation] = types.StringType
+		attributeValues[operation] = types.StringPointerValue(timeoutsV0[operation])
+	}
+
+	if timeoutsV0 == nil {
+		return timeouts.Value{Object: types.ObjectNull(attributeTypes)}
+	}
+
+	return timeouts.Value{Object: types.ObjectValueMust(attributeTypes, attributeValues)}
+
+}
@@ -0,0 +1,146 @@
+package buildonaws
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradedCharacterState holds the values expected after upgrading,
+// where empty strings stand for null values.
+type upgradedCharacterState struct {
+	ID            string
+	FullName      string
+	Identity      string
+	Universe      string
+	Tags          []string
+	CreatedAt     string
+	LastUpdated   string
+	CreateTimeout string
+	ReadTimeout   string
+}
+
+// TestCharacterResourceUpgradeState loads the fixtures in testdata, one per
+// shape of the state written by each prior version, and upgrades them to
+// the current version. New fixtures must be added whenever the schema
+// version is increased.
+func TestCharacterResourceUpgradeState(t *testing.T) {
+
+	fixtures := map[string]struct {
+		version  int64
+		expected upgradedCharacterState
+	}{
+		"v0_baseline.json": {
+			version: 0,
+			expected: upgradedCharacterState{
+				ID:          "g3UvQIoBdJ6Qq1Gm2Zkh",
+				FullName:    "Daredevil",
+				Identity:    "Matt Murdock",
+				LastUpdated: "2023-08-14T15:04:05Z",
+			},
+		},
+		"v0_universe_tags.json": {
+			version: 0,
+			expected: upgradedCharacterState{
+				ID:          "g3UvQIoBdJ6Qq1Gm2Zkh",
+				FullName:    "Daredevil",
+				Identity:    "Matt Murdock",
+				Universe:    "Earth-616",
+				Tags:        []string{"defenders", "new-york"},
+				LastUpdated: "2023-08-14T15:04:05Z",
+			},
+		},
+		"v0_timeouts_timestamps.json": {
+			version: 0,
+			expected: upgradedCharacterState{
+				ID:            "g3UvQIoBdJ6Qq1Gm2Zkh",
+				FullName:      "Daredevil",
+				Identity:      "Matt Murdock",
+				Universe:      "Earth-616",
+				Tags:          []string{"defenders"},
+				CreatedAt:     "2023-08-14T15:04:05Z",
+				LastUpdated:   "2023-08-15T10:00:00Z",
+				CreateTimeout: "1m0s",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	characterResource := &characterResource{}
+
+	schemaResponse := &resource.SchemaResponse{}
+	characterResource.Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+	currentSchema := schemaResponse.Schema
+
+	for fixture, testCase := range fixtures {
+		t.Run(fixture, func(t *testing.T) {
+
+			stateJSON, err := os.ReadFile(filepath.Join("testdata", "character_resource_state", fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stateUpgrader, found := characterResource.UpgradeState(ctx)[testCase.version]
+			if !found {
+				t.Fatalf("no state upgrader for version %d", testCase.version)
+			}
+
+			upgradeResponse := &resource.UpgradeStateResponse{
+				State: tfsdk.State{
+					Schema: currentSchema,
+					Raw:    tftypes.NewValue(currentSchema.Type().TerraformType(ctx), nil),
+				},
+			}
+			stateUpgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{
+				RawState: &tfprotov6.RawState{JSON: stateJSON},
+			}, upgradeResponse)
+			if upgradeResponse.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", upgradeResponse.Diagnostics)
+			}
+
+			var characterState CharacterResourceModel
+			diags := upgradeResponse.State.Get(ctx, &characterState)
+			if diags.HasError() {
+				t.Fatalf("the upgraded state does not match the current schema: %v", diags)
+			}
+
+			var tags []string
+			if !characterState.Tags.IsNull() {
+				characterState.Tags.ElementsAs(ctx, &tags, false)
+			}
+			createTimeout, _ := characterState.Timeouts.Create(ctx, 0)
+			readTimeout, _ := characterState.Timeouts.Read(ctx, 0)
+
+			actual := upgradedCharacterState{
+				ID:          characterState.ID.ValueString(),
+				FullName:    characterState.FullName.ValueString(),
+				Identity:    characterState.Identity.ValueString(),
+				Universe:    characterState.Universe.ValueString(),
+				Tags:        tags,
+				CreatedAt:   characterState.CreatedAt.ValueString(),
+				LastUpdated: characterState.LastUpdated.ValueString(),
+			}
+			if createTimeout != 0 {
+				actual.CreateTimeout = createTimeout.String()
+			}
+			if readTimeout != 0 {
+				actual.ReadTimeout = readTimeout.String()
+			}
+
+			sort.Strings(actual.Tags)
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, actual)
+			}
+
+		})
+	}
+
+}
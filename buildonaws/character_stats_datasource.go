@@ -0,0 +1,170 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ datasource.DataSource              = &characterStatsDataSource{}
+	_ datasource.DataSourceWithConfigure = &characterStatsDataSource{}
+)
+
+func NewCharacterStatsDataSource() datasource.DataSource {
+	return &characterStatsDataSource{}
+}
+
+type characterStatsDataSource struct {
+	backendClient *opensearch.Client
+}
+
+func (c *characterStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + characterStatsDataSourceTypeName
+}
+
+func (c *characterStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Computed: true,
+			},
+			totalField: schema.Int64Attribute{
+				Description: totalFieldDesc,
+				Computed:    true,
+			},
+			byTypeField: schema.MapAttribute{
+				Description: byTypeFieldDesc,
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			byTagField: schema.MapAttribute{
+				Description: byTagFieldDesc,
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			byUniverseField: schema.MapAttribute{
+				Description: byUniverseFieldDesc,
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (c *characterStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS character stats datasource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	c.backendClient = openSearchClientOf(req.ProviderData, characterStatsDataSourceTypeName, &resp.Diagnostics)
+
+}
+
+func (c *characterStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var characterStats CharacterStatsDataSourceModel
+
+	termsAggregation := func(field string) map[string]interface{} {
+		return map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": field + ".keyword",
+				"size":  aggregationBucketsSize,
+			},
+		}
+	}
+
+	searchBody := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": true,
+		"aggs": map[string]interface{}{
+			"types":     termsAggregation(typeField),
+			"tags":      termsAggregation(tagsField),
+			"universes": termsAggregation(universeField),
+		},
+	}
+
+	bodyContent, _ := json.Marshal(searchBody)
+	bodyReader := bytes.NewReader(bodyContent)
+	ignoreUnavailable := true
+	searchRequest := opensearchapi.SearchRequest{
+		Index:             []string{backendIndex},
+		Body:              bodyReader,
+		IgnoreUnavailable: &ignoreUnavailable,
+	}
+
+	searchResponse, err := searchRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while computing character stats",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	defer searchResponse.Body.Close()
+
+	bodyContent, err = io.ReadAll(searchResponse.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading response",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	if searchResponse.IsError() {
+		errorResponse := &BackendErrorResponse{}
+		json.Unmarshal(bodyContent, errorResponse)
+		resp.Diagnostics.AddError(
+			"Error while computing character stats",
+			"Reason: "+searchResponse.Status()+" "+errorResponse.Error.Type+": "+errorResponse.Error.Reason,
+		)
+		return
+	}
+
+	statsResponse := &BackendCharacterStatsResponse{}
+	err = json.Unmarshal(bodyContent, statsResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while unmarshalling response",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	bucketCounts := func(buckets []*BackendTermsBucket) map[string]types.Int64 {
+		counts := make(map[string]types.Int64, len(buckets))
+		for _, bucket := range buckets {
+			// Characters without a universe are stored with an empty one
+			if bucket.Key == "" {
+				continue
+			}
+			counts[bucket.Key] = types.Int64Value(bucket.DocCount)
+		}
+		return counts
+	}
+
+	characterStats.ID = types.StringValue(backendIndex)
+	characterStats.Total = types.Int64Value(statsResponse.Hits.Total.Value)
+	characterStats.ByType = bucketCounts(statsResponse.Aggregations.Types.Buckets)
+	characterStats.ByTag = bucketCounts(statsResponse.Aggregations.Tags.Buckets)
+	characterStats.ByUniverse = bucketCounts(statsResponse.Aggregations.Universes.Buckets)
+
+	diags := resp.State.Set(ctx, &characterStats)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
@@ -0,0 +1,102 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCharacterStatsDataSource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	characters := []*ComicCharacter{
+		{
+			FullName: "Star-Lord",
+			Identity: "Peter Quill",
+			Type:     characterTypes[0],
+			Universe: "guardians-stats",
+			Tags:     []string{"guardians-stats", "space"},
+		},
+		{
+			FullName: "Gamora",
+			Identity: "Gamora Zen Whoberi Ben Titan",
+			Type:     characterTypes[2],
+			Universe: "guardians-stats",
+			Tags:     []string{"guardians-stats"},
+		},
+	}
+
+	for _, character := range characters {
+		err = createCharacter(ctx, character, backendContainer)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	data "buildonaws_character_stats" "catalog" {
+	}`
+
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${backend_address}", backendContainer.Address)
+
+	dataSourceName := "data.buildonaws_character_stats.catalog"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: terrformConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify if the aggregated counts match with what is expected
+					resource.TestCheckResourceAttrSet(dataSourceName, totalField),
+					resource.TestCheckResourceAttrSet(dataSourceName, byTypeField+"."+characterTypes[0]),
+					resource.TestCheckResourceAttr(dataSourceName, byUniverseField+".guardians-stats", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, byTagField+".guardians-stats", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, byTagField+".space", "1"),
+				),
+			},
+		},
+	})
+
+}
+
+func TestCharacterStatsDataSourceBackendDenied(t *testing.T) {
+
+	backend := newFailingBackend(http.StatusForbidden)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + characterStatsDataSourceTypeName
+	readResponse, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   testDynamicValue(t, schemaResponse.DataSourceSchemas[typeName], map[string]tftypes.Value{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diagnostics := readResponse.Diagnostics
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while computing character stats" || !strings.Contains(diagnostics[0].Detail, "403") {
+		t.Errorf("expected the backend error to be reported, got %+v", diagnostics)
+	}
+
+}
@@ -0,0 +1,480 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var errCharacterNotFound = errors.New("character not found")
+
+// characterStore is implemented by each backend type capable of storing
+// characters, so the character resource and data source behave the same
+// regardless of which backend the provider has been configured with.
+type characterStore interface {
+	CreateCharacter(ctx context.Context, character *ComicCharacter) (string, error)
+	ReadCharacter(ctx context.Context, documentID string) (*ComicCharacter, error)
+	UpdateCharacter(ctx context.Context, documentID string, character *ComicCharacter) error
+	DeleteCharacter(ctx context.Context, documentID string) error
+	FindCharacterByIdentity(ctx context.Context, identity string) (*ComicCharacter, error)
+	FindCharactersByFullName(ctx context.Context, fullName string, universe string) ([]*ComicCharacter, error)
+	CharacterTypeExists(ctx context.Context, characterType string) (bool, error)
+	// ListCharacters hands the characters matching the filter over to the
+	// page function, one page at a time, until it returns false.
+	ListCharacters(ctx context.Context, filter characterFilter, size int, page func([]*ComicCharacter) bool) error
+}
+
+// characterFilter narrows down the characters being listed. Empty
+// fields match every character.
+type characterFilter struct {
+	FullName string
+	Type     string
+	Universe string
+}
+
+// matches compares fullnames and universes ignoring case, as hasFullName does.
+func (f characterFilter) matches(character *ComicCharacter) bool {
+	return (f.FullName == "" || strings.EqualFold(strings.TrimSpace(character.FullName), strings.TrimSpace(f.FullName))) &&
+		(f.Type == "" || character.Type == f.Type) &&
+		(f.Universe == "" || strings.EqualFold(strings.TrimSpace(character.Universe), strings.TrimSpace(f.Universe)))
+}
+
+type openSearchCharacterStore struct {
+	backendClient *opensearch.Client
+	refresh       string
+}
+
+func (s *openSearchCharacterStore) CreateCharacter(ctx context.Context, character *ComicCharacter) (string, error) {
+
+	bodyContent, err := json.Marshal(character)
+	if err != nil {
+		return "", err
+	}
+
+	indexRequest := opensearchapi.IndexRequest{
+		Index:   backendIndex,
+		Body:    bytes.NewReader(bodyContent),
+		Refresh: s.refresh,
+	}
+
+	indexResponse, err := indexRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return "", err
+	}
+	defer indexResponse.Body.Close()
+
+	backendResponse := &BackendResponse{}
+	err = decodeBackendResponse(indexResponse.StatusCode, indexResponse.Body, backendResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return backendResponse.ID, nil
+
+}
+
+func (s *openSearchCharacterStore) ReadCharacter(ctx context.Context, documentID string) (*ComicCharacter, error) {
+
+	getRequest := opensearchapi.GetRequest{
+		Index:      backendIndex,
+		DocumentID: documentID,
+	}
+
+	getResponse, err := getRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer getResponse.Body.Close()
+
+	if getResponse.StatusCode == http.StatusNotFound {
+		return nil, errCharacterNotFound
+	}
+
+	backendResponse := &BackendResponse{}
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, backendResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	backendResponse.Source.ID = backendResponse.ID
+	return backendResponse.Source, nil
+
+}
+
+func (s *openSearchCharacterStore) UpdateCharacter(ctx context.Context, documentID string, character *ComicCharacter) error {
+
+	updateBody := &struct {
+		Doc *ComicCharacter `json:"doc,omitempty"`
+	}{
+		Doc: character,
+	}
+
+	bodyContent, err := json.Marshal(updateBody)
+	if err != nil {
+		return err
+	}
+
+	updateRequest := opensearchapi.UpdateRequest{
+		Index:      backendIndex,
+		DocumentID: documentID,
+		Body:       bytes.NewReader(bodyContent),
+		Refresh:    s.refresh,
+	}
+
+	updateResponse, err := updateRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return err
+	}
+	defer updateResponse.Body.Close()
+
+	return decodeBackendResponse(updateResponse.StatusCode, updateResponse.Body, nil)
+
+}
+
+func (s *openSearchCharacterStore) DeleteCharacter(ctx context.Context, documentID string) error {
+
+	deleteRequest := opensearchapi.DeleteRequest{
+		Index:      backendIndex,
+		DocumentID: documentID,
+		Refresh:    s.refresh,
+	}
+
+	deleteResponse, err := deleteRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return err
+	}
+	defer deleteResponse.Body.Close()
+
+	if deleteResponse.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return decodeBackendResponse(deleteResponse.StatusCode, deleteResponse.Body, nil)
+
+}
+
+func (s *openSearchCharacterStore) FindCharacterByIdentity(ctx context.Context, identity string) (*ComicCharacter, error) {
+
+	backendSearchResponse, err := s.search(ctx, identitySearchBody(identity), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstCharacterHit(backendSearchResponse)
+
+}
+
+func (s *openSearchCharacterStore) FindCharactersByFullName(ctx context.Context, fullName string, universe string) ([]*ComicCharacter, error) {
+
+	backendSearchResponse, err := s.search(ctx, fullNameSearchBody(fullName), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return characterHitsWithFullName(backendSearchResponse, fullName, universe), nil
+
+}
+
+func (s *openSearchCharacterStore) CharacterTypeExists(ctx context.Context, characterType string) (bool, error) {
+
+	getRequest := opensearchapi.GetRequest{
+		Index:      characterTypeIndex,
+		DocumentID: characterType,
+	}
+
+	getResponse, err := getRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return false, err
+	}
+	defer getResponse.Body.Close()
+
+	if getResponse.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, nil)
+	return err == nil, err
+
+}
+
+// ListCharacters scrolls through the characters, as paging with from and
+// size stops at the max_result_window of the index.
+func (s *openSearchCharacterStore) ListCharacters(ctx context.Context, filter characterFilter, size int, page func([]*ComicCharacter) bool) error {
+
+	backendSearchResponse, err := s.search(ctx, listSearchBody(filter, size), listScrollKeepAlive)
+	if err != nil {
+		return err
+	}
+
+	scrollID := backendSearchResponse.ScrollID
+	defer func() {
+		s.clearScroll(ctx, scrollID)
+	}()
+
+	for nextCharacterPage(backendSearchResponse, size, page) {
+		backendSearchResponse, err = s.scroll(ctx, scrollID)
+		if err != nil {
+			return err
+		}
+		scrollID = backendSearchResponse.ScrollID
+	}
+
+	return nil
+
+}
+
+// search runs the query against the characters index, which may not exist
+// yet when no character has been stored. A scroll is opened when its keep
+// alive is not zero.
+func (s *openSearchCharacterStore) search(ctx context.Context, searchBody interface{}, scroll time.Duration) (*BackendSearchResponse, error) {
+
+	bodyContent, err := json.Marshal(searchBody)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreUnavailable := true
+	searchRequest := opensearchapi.SearchRequest{
+		Index:             []string{backendIndex},
+		Body:              bytes.NewReader(bodyContent),
+		IgnoreUnavailable: &ignoreUnavailable,
+		Scroll:            scroll,
+	}
+
+	searchResponse, err := searchRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer searchResponse.Body.Close()
+
+	backendSearchResponse := &BackendSearchResponse{}
+	err = decodeBackendResponse(searchResponse.StatusCode, searchResponse.Body, backendSearchResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return backendSearchResponse, nil
+
+}
+
+// scroll reads the next page of a scroll opened by search.
+func (s *openSearchCharacterStore) scroll(ctx context.Context, scrollID string) (*BackendSearchResponse, error) {
+
+	// Sent in the body, as scroll identifiers can be too long for the URL
+	bodyContent, err := json.Marshal(scrollBody(scrollID))
+	if err != nil {
+		return nil, err
+	}
+
+	scrollRequest := opensearchapi.ScrollRequest{
+		Body:   bytes.NewReader(bodyContent),
+		Scroll: listScrollKeepAlive,
+	}
+
+	scrollResponse, err := scrollRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer scrollResponse.Body.Close()
+
+	backendSearchResponse := &BackendSearchResponse{}
+	err = decodeBackendResponse(scrollResponse.StatusCode, scrollResponse.Body, backendSearchResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return backendSearchResponse, nil
+
+}
+
+// clearScroll releases the resources held by a scroll. Failures are
+// ignored, as the scroll expires anyway once its keep alive is over.
+func (s *openSearchCharacterStore) clearScroll(ctx context.Context, scrollID string) {
+
+	if scrollID == "" {
+		return
+	}
+
+	bodyContent, err := json.Marshal(clearScrollBody(scrollID))
+	if err != nil {
+		return
+	}
+
+	clearScrollRequest := opensearchapi.ClearScrollRequest{
+		Body: bytes.NewReader(bodyContent),
+	}
+
+	clearScrollResponse, err := clearScrollRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return
+	}
+	clearScrollResponse.Body.Close()
+
+}
+
+// identitySearchBody builds the query used to look characters up by
+// their identity, which is shared by all backends that speak the
+// OpenSearch and Elasticsearch query DSL.
+func identitySearchBody(identity string) interface{} {
+
+	searchBody := &struct {
+		Query struct {
+			Match struct {
+				Identity string `json:"identity,omitempty"`
+			} `json:"match,omitempty"`
+		} `json:"query,omitempty"`
+	}{}
+
+	searchBody.Query.Match.Identity = identity
+	return searchBody
+
+}
+
+// fullNameSearchBody builds the query used to look characters up by their
+// fullname. The match is loose, so hits are compared with the fullname by
+// characterHitsWithFullName afterwards.
+func fullNameSearchBody(fullName string) interface{} {
+
+	searchBody := &struct {
+		Size  int `json:"size"`
+		Query struct {
+			MatchPhrase struct {
+				FullName string `json:"fullname,omitempty"`
+			} `json:"match_phrase,omitempty"`
+		} `json:"query,omitempty"`
+	}{}
+
+	searchBody.Size = fullNameSearchSize
+	searchBody.Query.MatchPhrase.FullName = fullName
+	return searchBody
+
+}
+
+// listSearchBody builds the query used to scroll through the characters,
+// sorted in index order as it is the cheapest one. Phrases match loosely,
+// so hits are checked against the filter once the page is read.
+func listSearchBody(filter characterFilter, size int) interface{} {
+
+	filters := []interface{}{}
+	for _, phrase := range [][2]string{
+		{fullNameField, filter.FullName},
+		{typeField, filter.Type},
+		{universeField, filter.Universe},
+	} {
+		if phrase[1] != "" {
+			filters = append(filters, map[string]interface{}{
+				"match_phrase": map[string]string{phrase[0]: phrase[1]},
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"size": size,
+		"sort": []string{"_doc"},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": filters,
+			},
+		},
+	}
+
+}
+
+func scrollBody(scrollID string) interface{} {
+	return map[string]interface{}{"scroll_id": scrollID}
+}
+
+func clearScrollBody(scrollID string) interface{} {
+	return map[string]interface{}{"scroll_id": []string{scrollID}}
+}
+
+// nextCharacterPage hands the hits of a scroll over to the page function,
+// returning whether the next page has to be read. A short page means the
+// last one has been read.
+func nextCharacterPage(backendSearchResponse *BackendSearchResponse, size int, page func([]*ComicCharacter) bool) bool {
+	characters := characterHits(backendSearchResponse)
+	return len(characters) > 0 && page(characters) && len(characters) >= size
+}
+
+func characterHits(backendSearchResponse *BackendSearchResponse) []*ComicCharacter {
+
+	characters := []*ComicCharacter{}
+	for _, hit := range backendSearchResponse.Hits.Hits {
+		hit.Source.ID = hit.ID
+		characters = append(characters, hit.Source)
+	}
+
+	return characters
+
+}
+
+func characterHitsWithFullName(backendSearchResponse *BackendSearchResponse, fullName string, universe string) []*ComicCharacter {
+
+	characters := []*ComicCharacter{}
+	for _, hit := range backendSearchResponse.Hits.Hits {
+		if hasFullName(hit.Source, fullName, universe) {
+			hit.Source.ID = hit.ID
+			characters = append(characters, hit.Source)
+		}
+	}
+
+	return characters
+
+}
+
+// hasFullName compares fullnames and universes ignoring case, as
+// 'Spider-Man' and 'spider-man' name the same character to readers.
+func hasFullName(character *ComicCharacter, fullName string, universe string) bool {
+	return strings.EqualFold(strings.TrimSpace(character.FullName), strings.TrimSpace(fullName)) &&
+		strings.EqualFold(strings.TrimSpace(character.Universe), strings.TrimSpace(universe))
+}
+
+func firstCharacterHit(backendSearchResponse *BackendSearchResponse) (*ComicCharacter, error) {
+
+	if backendSearchResponse.Hits.Total.Value == 0 || len(backendSearchResponse.Hits.Hits) == 0 {
+		return nil, errCharacterNotFound
+	}
+
+	hit := backendSearchResponse.Hits.Hits[0]
+	hit.Source.ID = hit.ID
+	return hit.Source, nil
+
+}
+
+// decodeBackendResponse reads the body of a response sent by the backend,
+// turning error statuses into errors and unmarshalling it when needed.
+func decodeBackendResponse(statusCode int, body io.Reader, target interface{}) error {
+
+	bodyContent, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("error while reading response: %w", err)
+	}
+
+	if statusCode >= http.StatusBadRequest {
+		errorResponse := &BackendErrorResponse{}
+		json.Unmarshal(bodyContent, errorResponse)
+		return fmt.Errorf("unexpected status '%d %s' from the backend: %s %s", statusCode,
+			http.StatusText(statusCode), errorResponse.Error.Type, errorResponse.Error.Reason)
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(bodyContent, target)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling response: %w", err)
+	}
+
+	return nil
+
+}
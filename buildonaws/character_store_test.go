@@ -0,0 +1,632 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/opensearch-project/opensearch-go/v2"
+)
+
+// backendStub mimics the document APIs of OpenSearch and Elasticsearch,
+// which share the same response shapes, keeping documents in memory.
+type backendStub struct {
+	mutex      sync.Mutex
+	documents  map[string]*ComicCharacter
+	lastID     int
+	types      map[string]bool
+	infoResult string
+	refreshes  []string
+	apiKeys    map[string]bool
+	// authorizations holds the Authorization header of every request
+	authorizations []string
+	// indexes holds the mappings of the indexes created explicitly
+	indexes          map[string]string
+	refreshedIndexes []string
+	// tasks holds how many times each reindex task is polled before completing
+	tasks map[string]int
+	// aliases holds the indexes each alias points to
+	aliases map[string]map[string]bool
+	// repositories holds the snapshots of each repository, with how many
+	// times their status is read before they complete
+	repositories map[string]map[string]int
+	// snapshottedIndexes holds the indexes of every snapshot taken
+	snapshottedIndexes []string
+	// scrolls holds the hits left to read by each open scroll
+	scrolls      map[string][]map[string]interface{}
+	scrollSizes  map[string]int
+	lastScrollID int
+}
+
+func newBackendStub() *backendStub {
+	return &backendStub{
+		documents:    map[string]*ComicCharacter{},
+		types:        map[string]bool{"sidekick": true},
+		infoResult:   openSearch2InfoResponse,
+		apiKeys:      map[string]bool{},
+		indexes:      map[string]string{},
+		tasks:        map[string]int{},
+		aliases:      map[string]map[string]bool{},
+		repositories: map[string]map[string]int{},
+		scrolls:      map[string][]map[string]interface{}{},
+		scrollSizes:  map[string]int{},
+	}
+}
+
+func (b *backendStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+	writeJSON := func(statusCode int, body interface{}) {
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(body)
+	}
+	notFound := map[string]interface{}{"found": false}
+
+	b.authorizations = append(b.authorizations, r.Header.Get("Authorization"))
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	// Writes are the only requests sent with a method other than GET to documents
+	if r.Method != http.MethodGet && parts[0] != "_snapshot" && (len(parts) == 3 || len(parts) == 2 && parts[1] == "_doc") {
+		b.refreshes = append(b.refreshes, r.URL.Query().Get("refresh"))
+	}
+	switch {
+
+	case r.URL.Path == "/":
+		w.Write([]byte(b.infoResult))
+
+	case r.URL.Path == "/_security/api_key" && r.Method == http.MethodPut:
+		apiKeyRequest := &struct {
+			Name       string `json:"name"`
+			Expiration string `json:"expiration"`
+		}{}
+		json.NewDecoder(r.Body).Decode(apiKeyRequest)
+		expiration, _ := time.ParseDuration(apiKeyRequest.Expiration)
+		b.lastID++
+		apiKeyID := "key-" + strconv.Itoa(b.lastID)
+		b.apiKeys[apiKeyID] = true
+		writeJSON(http.StatusOK, map[string]interface{}{
+			"id":         apiKeyID,
+			"name":       apiKeyRequest.Name,
+			"expiration": time.Now().Add(expiration).UnixMilli(),
+			"api_key":    "secret-" + apiKeyID,
+			"encoded":    base64.StdEncoding.EncodeToString([]byte(apiKeyID + ":secret-" + apiKeyID)),
+		})
+
+	case r.URL.Path == "/_security/api_key" && r.Method == http.MethodDelete:
+		invalidateRequest := &struct {
+			IDs []string `json:"ids"`
+		}{}
+		json.NewDecoder(r.Body).Decode(invalidateRequest)
+		invalidated, errorCount := []string{}, 0
+		for _, apiKeyID := range invalidateRequest.IDs {
+			if !b.apiKeys[apiKeyID] {
+				errorCount++
+				continue
+			}
+			b.apiKeys[apiKeyID] = false
+			invalidated = append(invalidated, apiKeyID)
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{
+			"invalidated_api_keys": invalidated,
+			"error_count":          errorCount,
+		})
+
+	case len(parts) == 2 && parts[1] == "_refresh" && r.Method == http.MethodPost:
+		b.refreshedIndexes = append(b.refreshedIndexes, parts[0])
+		writeJSON(http.StatusOK, map[string]interface{}{
+			"_shards": map[string]interface{}{"total": 2, "successful": 1, "failed": 0},
+		})
+
+	case len(parts) == 3 && parts[0] == "_snapshot" && r.Method == http.MethodPut:
+		snapshots, found := b.repositories[parts[1]]
+		if !found {
+			writeJSON(http.StatusNotFound, map[string]interface{}{
+				"error": map[string]interface{}{"type": "repository_missing_exception", "reason": "[" + parts[1] + "] missing"},
+			})
+			return
+		}
+		if _, found := snapshots[parts[2]]; found {
+			writeJSON(http.StatusBadRequest, map[string]interface{}{
+				"error": map[string]interface{}{"type": "invalid_snapshot_name_exception", "reason": "snapshot with the same name already exists"},
+			})
+			return
+		}
+		snapshotRequest := &struct {
+			Indices string `json:"indices"`
+		}{}
+		json.NewDecoder(r.Body).Decode(snapshotRequest)
+		b.snapshottedIndexes = append(b.snapshottedIndexes, snapshotRequest.Indices)
+		snapshots[parts[2]] = 2
+		writeJSON(http.StatusOK, map[string]interface{}{"accepted": true})
+
+	case len(parts) == 4 && parts[0] == "_snapshot" && parts[3] == "_status":
+		remainingPolls, found := b.repositories[parts[1]][parts[2]]
+		if !found {
+			writeJSON(http.StatusNotFound, map[string]interface{}{
+				"error": map[string]interface{}{"type": "snapshot_missing_exception", "reason": "[" + parts[1] + ":" + parts[2] + "] is missing"},
+			})
+			return
+		}
+		state, done := "SUCCESS", 2
+		if remainingPolls > 0 {
+			b.repositories[parts[1]][parts[2]] = remainingPolls - 1
+			state, done = "STARTED", 2-remainingPolls
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{
+			"snapshots": []interface{}{map[string]interface{}{
+				"snapshot":     parts[2],
+				"state":        state,
+				"shards_stats": map[string]interface{}{"done": done, "failed": 0, "total": 2},
+			}},
+		})
+
+	case len(parts) == 2 && parts[0] == "_alias" && r.Method == http.MethodGet:
+		if len(b.aliases[parts[1]]) == 0 {
+			writeJSON(http.StatusNotFound, map[string]interface{}{"error": "alias [" + parts[1] + "] missing", "status": 404})
+			return
+		}
+		aliasedIndexes := map[string]interface{}{}
+		for index := range b.aliases[parts[1]] {
+			aliasedIndexes[index] = map[string]interface{}{"aliases": map[string]interface{}{parts[1]: map[string]interface{}{}}}
+		}
+		writeJSON(http.StatusOK, aliasedIndexes)
+
+	case len(parts) == 1 && parts[0] == "_aliases" && r.Method == http.MethodPost:
+		aliasesRequest := &struct {
+			Actions []map[string]struct {
+				Index string `json:"index"`
+				Alias string `json:"alias"`
+			} `json:"actions"`
+		}{}
+		json.NewDecoder(r.Body).Decode(aliasesRequest)
+		// Every action is checked before any is applied, as the backend does
+		for _, action := range aliasesRequest.Actions {
+			if add, found := action["add"]; found {
+				if _, isIndex := b.indexes[add.Alias]; isIndex || add.Alias == backendIndex {
+					writeJSON(http.StatusBadRequest, map[string]interface{}{
+						"error": map[string]interface{}{"type": "invalid_alias_name_exception", "reason": "an index exists with the same name as the alias"},
+					})
+					return
+				}
+			}
+			if remove, found := action["remove"]; found && !b.aliases[remove.Alias][remove.Index] {
+				writeJSON(http.StatusNotFound, map[string]interface{}{
+					"error": map[string]interface{}{"type": "aliases_not_found_exception", "reason": "aliases [" + remove.Alias + "] missing"},
+				})
+				return
+			}
+		}
+		for _, action := range aliasesRequest.Actions {
+			if remove, found := action["remove"]; found {
+				delete(b.aliases[remove.Alias], remove.Index)
+			}
+			if add, found := action["add"]; found {
+				if b.aliases[add.Alias] == nil {
+					b.aliases[add.Alias] = map[string]bool{}
+				}
+				b.aliases[add.Alias][add.Index] = true
+			}
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{"acknowledged": true})
+
+	case len(parts) == 1 && parts[0] == "_reindex" && r.Method == http.MethodPost:
+		reindexRequest := &struct {
+			Dest struct {
+				Index string `json:"index"`
+			} `json:"dest"`
+		}{}
+		json.NewDecoder(r.Body).Decode(reindexRequest)
+		if _, found := b.indexes[reindexRequest.Dest.Index]; !found {
+			b.indexes[reindexRequest.Dest.Index] = ""
+		}
+		b.lastID++
+		taskID := "node:" + strconv.Itoa(b.lastID)
+		b.tasks[taskID] = 2
+		writeJSON(http.StatusOK, map[string]interface{}{"task": taskID})
+
+	case len(parts) == 2 && parts[0] == "_tasks":
+		remainingPolls, found := b.tasks[parts[1]]
+		if !found {
+			writeJSON(http.StatusNotFound, map[string]interface{}{
+				"error": map[string]interface{}{"type": "resource_not_found_exception", "reason": "task not found"},
+			})
+			return
+		}
+		total := len(b.documents)
+		if remainingPolls > 0 {
+			b.tasks[parts[1]] = remainingPolls - 1
+			writeJSON(http.StatusOK, map[string]interface{}{
+				"completed": false,
+				"task": map[string]interface{}{
+					"status": map[string]interface{}{"total": total, "created": total / (remainingPolls + 1), "updated": 0},
+				},
+			})
+			return
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{
+			"completed": true,
+			"response":  map[string]interface{}{"total": total, "created": total, "updated": 0, "failures": []interface{}{}},
+		})
+
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		if _, found := b.indexes[parts[0]]; found {
+			writeJSON(http.StatusBadRequest, map[string]interface{}{
+				"error": map[string]interface{}{"type": "resource_already_exists_exception", "reason": "index already exists"},
+			})
+			return
+		}
+		createRequest := &struct {
+			Mappings json.RawMessage `json:"mappings"`
+		}{}
+		json.NewDecoder(r.Body).Decode(createRequest)
+		b.indexes[parts[0]] = string(createRequest.Mappings)
+		writeJSON(http.StatusOK, map[string]interface{}{"acknowledged": true, "index": parts[0]})
+
+	case len(parts) == 3 && parts[0] == characterTypeIndex && parts[1] == "_doc":
+		if !b.types[parts[2]] {
+			writeJSON(http.StatusNotFound, notFound)
+			return
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{"_id": parts[2], "found": true})
+
+	case len(parts) == 2 && parts[1] == "_doc" && r.Method == http.MethodPost:
+		character := &ComicCharacter{}
+		json.NewDecoder(r.Body).Decode(character)
+		b.lastID++
+		documentID := strconv.Itoa(b.lastID)
+		b.documents[documentID] = character
+		writeJSON(http.StatusCreated, map[string]interface{}{"_id": documentID, "result": "created"})
+
+	case len(parts) == 3 && parts[1] == "_doc" && r.Method == http.MethodGet:
+		character, found := b.documents[parts[2]]
+		if !found {
+			writeJSON(http.StatusNotFound, notFound)
+			return
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{"_id": parts[2], "found": true, "_source": character})
+
+	case len(parts) == 3 && parts[1] == "_doc" && r.Method == http.MethodDelete:
+		if _, found := b.documents[parts[2]]; !found {
+			writeJSON(http.StatusNotFound, map[string]interface{}{"result": "not_found"})
+			return
+		}
+		delete(b.documents, parts[2])
+		writeJSON(http.StatusOK, map[string]interface{}{"_id": parts[2], "result": "deleted"})
+
+	case len(parts) == 3 && parts[1] == "_update":
+		if _, found := b.documents[parts[2]]; !found {
+			writeJSON(http.StatusNotFound, map[string]interface{}{
+				"error":  map[string]interface{}{"type": "document_missing_exception", "reason": "document missing"},
+				"status": http.StatusNotFound,
+			})
+			return
+		}
+		// Decoding into a copy of the stored document merges both of them
+		storedCharacter := *b.documents[parts[2]]
+		updateBody := &struct {
+			Doc *ComicCharacter `json:"doc"`
+		}{Doc: &storedCharacter}
+		json.NewDecoder(r.Body).Decode(updateBody)
+		b.documents[parts[2]] = updateBody.Doc
+		writeJSON(http.StatusOK, map[string]interface{}{"_id": parts[2], "result": "updated"})
+
+	case len(parts) == 2 && parts[0] == "_search" && parts[1] == "scroll" && r.Method == http.MethodPost:
+		scrollBody := &struct {
+			ScrollID string `json:"scroll_id"`
+		}{}
+		json.NewDecoder(r.Body).Decode(scrollBody)
+		hits, found := b.scrolls[scrollBody.ScrollID]
+		if !found {
+			writeJSON(http.StatusNotFound, map[string]interface{}{
+				"error": map[string]interface{}{"type": "search_context_missing_exception", "reason": "No search context found"},
+			})
+			return
+		}
+		writeJSON(http.StatusOK, b.scrollPage(scrollBody.ScrollID, hits))
+
+	case len(parts) == 2 && parts[0] == "_search" && parts[1] == "scroll" && r.Method == http.MethodDelete:
+		clearScrollBody := &struct {
+			ScrollID []string `json:"scroll_id"`
+		}{}
+		json.NewDecoder(r.Body).Decode(clearScrollBody)
+		for _, scrollID := range clearScrollBody.ScrollID {
+			delete(b.scrolls, scrollID)
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{"succeeded": true, "num_freed": len(clearScrollBody.ScrollID)})
+
+	case len(parts) == 2 && parts[1] == "_search":
+		searchBody := &struct {
+			Size  int `json:"size"`
+			Query struct {
+				Match struct {
+					Identity string `json:"identity"`
+				} `json:"match"`
+				MatchPhrase struct {
+					FullName string `json:"fullname"`
+				} `json:"match_phrase"`
+				Bool *struct {
+					Filter []struct {
+						MatchPhrase map[string]string `json:"match_phrase"`
+					} `json:"filter"`
+				} `json:"bool"`
+			} `json:"query"`
+		}{}
+		json.NewDecoder(r.Body).Decode(searchBody)
+		// Sorted so pages do not overlap, like sorting by '_doc' does
+		documentIDs := make([]string, 0, len(b.documents))
+		for documentID := range b.documents {
+			documentIDs = append(documentIDs, documentID)
+		}
+		sort.Strings(documentIDs)
+		hits := []map[string]interface{}{}
+		for _, documentID := range documentIDs {
+			character := b.documents[documentID]
+			// Phrases are matched ignoring case, like the analyzers of the backend do
+			matched := character.Identity == searchBody.Query.Match.Identity
+			if searchBody.Query.MatchPhrase.FullName != "" {
+				matched = strings.EqualFold(character.FullName, searchBody.Query.MatchPhrase.FullName)
+			}
+			if searchBody.Query.Bool != nil {
+				fields := map[string]string{
+					fullNameField: character.FullName,
+					typeField:     character.Type,
+					universeField: character.Universe,
+				}
+				matched = true
+				for _, filter := range searchBody.Query.Bool.Filter {
+					for field, phrase := range filter.MatchPhrase {
+						matched = matched && strings.Contains(strings.ToLower(fields[field]), strings.ToLower(phrase))
+					}
+				}
+			}
+			if matched {
+				hits = append(hits, map[string]interface{}{"_id": documentID, "_source": character})
+			}
+		}
+		if r.URL.Query().Get("scroll") != "" {
+			b.lastScrollID++
+			scrollID := "scroll-" + strconv.Itoa(b.lastScrollID)
+			b.scrollSizes[scrollID] = searchBody.Size
+			writeJSON(http.StatusOK, b.scrollPage(scrollID, hits))
+			return
+		}
+		writeJSON(http.StatusOK, map[string]interface{}{
+			"hits": map[string]interface{}{
+				"total": map[string]interface{}{"value": len(hits)},
+				"hits":  hits,
+			},
+		})
+
+	default:
+		writeJSON(http.StatusBadRequest, map[string]interface{}{
+			"error": map[string]interface{}{"type": "illegal_argument_exception", "reason": "unexpected request " + r.URL.Path},
+		})
+
+	}
+
+}
+
+// scrollPage returns the next page of the scroll, keeping the hits left
+// to read for the next scroll request.
+func (b *backendStub) scrollPage(scrollID string, hits []map[string]interface{}) map[string]interface{} {
+
+	size := b.scrollSizes[scrollID]
+	b.scrolls[scrollID] = hits[min(size, len(hits)):]
+	return map[string]interface{}{
+		"_scroll_id": scrollID,
+		"hits": map[string]interface{}{
+			"total": map[string]interface{}{"value": len(hits)},
+			"hits":  hits[:min(size, len(hits))],
+		},
+	}
+
+}
+
+func TestCharacterStores(t *testing.T) {
+
+	newStores := map[string]func(address string) (characterStore, error){
+		backendTypeOpenSearch: func(address string) (characterStore, error) {
+			backendClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{address}})
+			return &openSearchCharacterStore{backendClient: backendClient, refresh: refreshWaitFor}, err
+		},
+		backendTypeElastic: func(address string) (characterStore, error) {
+			backendClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{address}})
+			return &elasticsearchCharacterStore{backendClient: backendClient, refresh: refreshWaitFor}, err
+		},
+	}
+
+	for backendType, newStore := range newStores {
+		t.Run(backendType, func(t *testing.T) {
+
+			stub := newBackendStub()
+			backend := httptest.NewServer(stub)
+			defer backend.Close()
+
+			store, err := newStore(backend.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testCharacterStore(t, store)
+
+			// Every scroll opened to list characters must be cleared
+			if len(stub.scrolls) != 0 {
+				t.Errorf("list: expected every scroll to be cleared, got %v", stub.scrolls)
+			}
+
+			// Every write must carry the refresh policy
+			if len(stub.refreshes) == 0 {
+				t.Error("refresh: expected writes to be sent to the backend")
+			}
+			for _, refresh := range stub.refreshes {
+				if refresh != refreshWaitFor {
+					t.Errorf("refresh: expected '%s', got '%s'", refreshWaitFor, refresh)
+				}
+			}
+
+		})
+	}
+
+}
+
+func testCharacterStore(t *testing.T, store characterStore) {
+
+	ctx := context.Background()
+
+	character := &ComicCharacter{
+		FullName:  "Daredevil",
+		Identity:  "Matt Murdock",
+		KnownAs:   "The man without fear",
+		Type:      characterTypes[1],
+		Universe:  "Earth-616",
+		Tags:      []string{"defenders"},
+		CreatedAt: "2026-01-02T03:04:05Z",
+	}
+
+	documentID, err := store.CreateCharacter(ctx, character)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if documentID == "" {
+		t.Fatal("create: expected a document identifier")
+	}
+
+	storedCharacter, err := store.ReadCharacter(ctx, documentID)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if storedCharacter.ID != documentID || storedCharacter.FullName != character.FullName {
+		t.Errorf("read: unexpected character %+v", storedCharacter)
+	}
+
+	character.KnownAs = ""
+	character.Universe = ""
+	character.CreatedAt = ""
+	err = store.UpdateCharacter(ctx, documentID, character)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	foundCharacter, err := store.FindCharacterByIdentity(ctx, character.Identity)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if foundCharacter.ID != documentID || foundCharacter.FullName != character.FullName {
+		t.Errorf("find: unexpected character %+v", foundCharacter)
+	}
+	if foundCharacter.KnownAs != "" || foundCharacter.Universe != "" {
+		t.Errorf("update: expected the known as and universe to be cleared, got '%s' and '%s'", foundCharacter.KnownAs, foundCharacter.Universe)
+	}
+	if foundCharacter.CreatedAt != "2026-01-02T03:04:05Z" {
+		t.Errorf("update: expected fields left empty to keep their value, got created_at '%s'", foundCharacter.CreatedAt)
+	}
+
+	_, err = store.FindCharacterByIdentity(ctx, "Wade Wilson")
+	if err != errCharacterNotFound {
+		t.Errorf("find: expected character not found, got: %v", err)
+	}
+
+	foundCharacters, err := store.FindCharactersByFullName(ctx, "daredevil", "")
+	if err != nil {
+		t.Fatalf("find by fullname: %v", err)
+	}
+	if len(foundCharacters) != 1 || foundCharacters[0].ID != documentID {
+		t.Errorf("find by fullname: expected the character '%s', got %+v", documentID, foundCharacters)
+	}
+	foundCharacters, err = store.FindCharactersByFullName(ctx, "Daredevil", "Earth-616")
+	if err != nil || len(foundCharacters) != 0 {
+		t.Errorf("find by fullname in another universe: expected no characters, got %+v, %v", foundCharacters, err)
+	}
+
+	otherDocumentID, err := store.CreateCharacter(ctx, &ComicCharacter{
+		FullName: "Daredevil 2099",
+		Identity: "Samuel Smithers",
+		Type:     characterTypes[0],
+		Universe: "Earth-928",
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var pages [][]*ComicCharacter
+	err = store.ListCharacters(ctx, characterFilter{}, 1, func(characters []*ComicCharacter) bool {
+		pages = append(pages, characters)
+		return true
+	})
+	if err != nil || len(pages) != 2 || len(pages[0]) != 1 || len(pages[1]) != 1 || pages[0][0].ID == pages[1][0].ID {
+		t.Fatalf("list: expected two pages with one character each, got %+v, %v", pages, err)
+	}
+
+	// Listing stops as soon as the page function returns false
+	pages = nil
+	err = store.ListCharacters(ctx, characterFilter{}, 1, func(characters []*ComicCharacter) bool {
+		pages = append(pages, characters)
+		return false
+	})
+	if err != nil || len(pages) != 1 {
+		t.Errorf("list: expected a single page to be read, got %+v, %v", pages, err)
+	}
+
+	// Phrases may match loosely, but the matching characters must be listed
+	filter := characterFilter{FullName: "daredevil 2099", Universe: "earth-928"}
+	listedCharacters := []*ComicCharacter{}
+	err = store.ListCharacters(ctx, filter, fullNameSearchSize, func(characters []*ComicCharacter) bool {
+		listedCharacters = append(listedCharacters, characters...)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("list with filter: %v", err)
+	}
+	matchedIDs := []string{}
+	for _, listedCharacter := range listedCharacters {
+		if filter.matches(listedCharacter) {
+			matchedIDs = append(matchedIDs, listedCharacter.ID)
+		}
+	}
+	if len(matchedIDs) != 1 || matchedIDs[0] != otherDocumentID || listedCharacters[0].Identity == "" {
+		t.Errorf("list with filter: expected the character '%s', got %+v", otherDocumentID, listedCharacters)
+	}
+
+	err = store.DeleteCharacter(ctx, otherDocumentID)
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	exists, err := store.CharacterTypeExists(ctx, "sidekick")
+	if err != nil || !exists {
+		t.Errorf("type exists: expected 'sidekick' to exist, got: %v, %v", exists, err)
+	}
+	exists, err = store.CharacterTypeExists(ctx, "henchman")
+	if err != nil || exists {
+		t.Errorf("type exists: expected 'henchman' not to exist, got: %v, %v", exists, err)
+	}
+
+	err = store.DeleteCharacter(ctx, documentID)
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	_, err = store.ReadCharacter(ctx, documentID)
+	if err != errCharacterNotFound {
+		t.Errorf("read after delete: expected character not found, got: %v", err)
+	}
+
+	err = store.UpdateCharacter(ctx, documentID, character)
+	if err == nil || !strings.Contains(err.Error(), "document_missing_exception") {
+		t.Errorf("update after delete: expected a backend error, got: %v", err)
+	}
+
+}
@@ -0,0 +1,291 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ resource.Resource                = &characterTypeResource{}
+	_ resource.ResourceWithConfigure   = &characterTypeResource{}
+	_ resource.ResourceWithImportState = &characterTypeResource{}
+)
+
+func NewCharacterTypeResource() resource.Resource {
+	return &characterTypeResource{}
+}
+
+type characterTypeResource struct {
+	backendClient *opensearch.Client
+	refresh       string
+}
+
+func (c *characterTypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + characterTypeResourceTypeName
+}
+
+func (c *characterTypeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Description: characterTypeIDFieldDesc,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			nameField: schema.StringAttribute{
+				Description: nameFieldDesc,
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			descriptionField: schema.StringAttribute{
+				Description: descriptionFieldDesc,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (c *characterTypeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS character type resource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	c.backendClient = openSearchClientOf(req.ProviderData, characterTypeResourceTypeName, &resp.Diagnostics)
+	c.refresh = req.ProviderData.(*backend).refresh
+
+}
+
+func (c *characterTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root(idField), req, resp)
+}
+
+func (c *characterTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var characterTypePlan CharacterTypeResourceModel
+	diags := req.Plan.Get(ctx, &characterTypePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	comicCharacterType := &ComicCharacterType{
+		Name:        characterTypePlan.Name.ValueString(),
+		Description: characterTypePlan.Description.ValueString(),
+	}
+	bodyContent, _ := json.Marshal(comicCharacterType)
+	bodyReader := bytes.NewReader(bodyContent)
+	indexRequest := opensearchapi.IndexRequest{
+		Index:      characterTypeIndex,
+		DocumentID: comicCharacterType.Name,
+		OpType:     "create",
+		Body:       bodyReader,
+		Refresh:    c.refresh,
+	}
+
+	indexResponse, err := indexRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	defer indexResponse.Body.Close()
+	if indexResponse.StatusCode == http.StatusConflict {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(nameField),
+			"Character type already exists",
+			"A character type named '"+comicCharacterType.Name+"' is already stored in the backend.",
+		)
+		return
+	}
+
+	backendResponse := &BackendCharacterTypeResponse{}
+	err = decodeBackendResponse(indexResponse.StatusCode, indexResponse.Body, backendResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	characterTypePlan.ID = types.StringValue(backendResponse.ID)
+	characterTypePlan.Description = types.StringValue(comicCharacterType.Description)
+
+	diags = resp.State.Set(ctx, characterTypePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (c *characterTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var characterTypeState CharacterTypeResourceModel
+	diags := req.State.Get(ctx, &characterTypeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getRequest := opensearchapi.GetRequest{
+		Index:      characterTypeIndex,
+		DocumentID: characterTypeState.ID.ValueString(),
+	}
+
+	getResponse, err := getRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	defer getResponse.Body.Close()
+	if getResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	backendResponse := &BackendCharacterTypeResponse{}
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, backendResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	characterTypeState.Name = types.StringValue(backendResponse.Source.Name)
+	characterTypeState.Description = types.StringValue(backendResponse.Source.Description)
+
+	diags = resp.State.Set(ctx, &characterTypeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (c *characterTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var characterTypePlan CharacterTypeResourceModel
+	diags := req.Plan.Get(ctx, &characterTypePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	comicCharacterType := &ComicCharacterType{
+		Name:        characterTypePlan.Name.ValueString(),
+		Description: characterTypePlan.Description.ValueString(),
+	}
+	bodyContent, err := json.Marshal(comicCharacterType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while marshalling request",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	bodyReader := bytes.NewReader(bodyContent)
+	indexRequest := opensearchapi.IndexRequest{
+		Index:      characterTypeIndex,
+		DocumentID: characterTypePlan.ID.ValueString(),
+		Body:       bodyReader,
+		Refresh:    c.refresh,
+	}
+
+	indexResponse, err := indexRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while updating character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	defer indexResponse.Body.Close()
+	err = decodeBackendResponse(indexResponse.StatusCode, indexResponse.Body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while updating character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	characterTypePlan.Description = types.StringValue(comicCharacterType.Description)
+
+	diags = resp.State.Set(ctx, characterTypePlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (c *characterTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	var characterTypeState CharacterTypeResourceModel
+	diags := req.State.Get(ctx, &characterTypeState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteRequest := opensearchapi.DeleteRequest{
+		Index:      characterTypeIndex,
+		DocumentID: characterTypeState.ID.ValueString(),
+		Refresh:    c.refresh,
+	}
+	deleteResponse, err := deleteRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while deleting character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	// A character type already deleted outside Terraform is gone either way
+	defer deleteResponse.Body.Close()
+	if deleteResponse.StatusCode == http.StatusNotFound {
+		return
+	}
+
+	err = decodeBackendResponse(deleteResponse.StatusCode, deleteResponse.Body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while deleting character type",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+}
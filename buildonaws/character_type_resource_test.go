@@ -0,0 +1,168 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCharacterTypeResource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	terraformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	resource "buildonaws_character_type" "sidekick" {
+		name = "sidekick"
+		description = "${character_type_description}"
+	}
+
+	resource "buildonaws_character" "robin" {
+		fullname = "Robin"
+		identity = "Dick Grayson"
+		knownas = "The boy wonder"
+		type = buildonaws_character_type.sidekick.id
+	}`
+
+	invalidTypeConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	resource "buildonaws_character" "robin" {
+		fullname = "Robin"
+		identity = "Dick Grayson"
+		knownas = "The boy wonder"
+		type = "henchman"
+	}`
+
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${backend_address}", backendContainer.Address)
+	invalidTypeConfig = strings.ReplaceAll(invalidTypeConfig, "${backend_address}", backendContainer.Address)
+
+	tfConfigCreateReadTest := strings.ReplaceAll(terraformConfig, "${character_type_description}", "Helps the hero")
+	tfConfigUpdateReadTest := strings.ReplaceAll(terraformConfig, "${character_type_description}", "Fights alongside the hero")
+
+	resourceName := "buildonaws_character_type.sidekick"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Types not registered in the backend are rejected when applied
+			{
+				Config:      invalidTypeConfig,
+				ExpectError: regexp.MustCompile("Invalid character type"),
+			},
+			// Create and Read testing
+			{
+				Config: tfConfigCreateReadTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify if the fields match with what is expected
+					resource.TestCheckResourceAttr(resourceName, idField, "sidekick"),
+					resource.TestCheckResourceAttr(resourceName, nameField, "sidekick"),
+					resource.TestCheckResourceAttr(resourceName, descriptionField, "Helps the hero"),
+					resource.TestCheckResourceAttr("buildonaws_character.robin", typeField, "sidekick"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: tfConfigUpdateReadTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, descriptionField, "Fights alongside the hero"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+
+}
+
+func TestCharacterTypeResourceBackendErrors(t *testing.T) {
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + characterTypeResourceTypeName
+	characterType := func(name string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			idField:          tftypes.NewValue(tftypes.String, name),
+			nameField:        tftypes.NewValue(tftypes.String, name),
+			descriptionField: tftypes.NewValue(tftypes.String, "Helps the hero"),
+		}
+	}
+
+	diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName, characterType("sidekick"), characterType("sidekick"))
+	for _, diagnostic := range diagnostics {
+		t.Errorf("update: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	// The stub only answers with the documents of the types it knows
+	diagnostics = applyTestResourceChange(t, server, schemaResponse, typeName, characterType("henchman"), characterType("henchman"))
+	if len(diagnostics) == 0 || !strings.Contains(diagnostics[0].Detail, "404") {
+		t.Errorf("update: expected the backend error to be reported, got %+v", diagnostics)
+	}
+
+	// Deleting a character type already gone is not an error
+	diagnostics = applyTestResourceChange(t, server, schemaResponse, typeName, characterType("henchman"), nil)
+	for _, diagnostic := range diagnostics {
+		t.Errorf("delete: unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+}
+
+func TestCharacterTypeResourceBackendDenied(t *testing.T) {
+
+	backend := newFailingBackend(http.StatusForbidden)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + characterTypeResourceTypeName
+	characterType := func(documentID tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			idField:          documentID,
+			nameField:        tftypes.NewValue(tftypes.String, "henchman"),
+			descriptionField: tftypes.NewValue(tftypes.String, "Works for the villain"),
+		}
+	}
+
+	diagnostics := applyTestResourceChange(t, server, schemaResponse, typeName, nil,
+		characterType(tftypes.NewValue(tftypes.String, tftypes.UnknownValue)))
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while creating character type" || !strings.Contains(diagnostics[0].Detail, "403") {
+		t.Errorf("create: expected the backend error to be reported, got %+v", diagnostics)
+	}
+
+	diagnostics = readTestResource(t, server, schemaResponse, typeName, characterType(tftypes.NewValue(tftypes.String, "henchman")))
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while reading character type" || !strings.Contains(diagnostics[0].Detail, "403") {
+		t.Errorf("read: expected the backend error to be reported, got %+v", diagnostics)
+	}
+
+}
@@ -0,0 +1,171 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ datasource.DataSource              = &clusterDataSource{}
+	_ datasource.DataSourceWithConfigure = &clusterDataSource{}
+)
+
+func NewClusterDataSource() datasource.DataSource {
+	return &clusterDataSource{}
+}
+
+type clusterDataSource struct {
+	backendClient *opensearch.Client
+}
+
+func (c *clusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + clusterDataSourceTypeName
+}
+
+func (c *clusterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Computed: true,
+			},
+			clusterNameField: schema.StringAttribute{
+				Description: clusterNameFieldDesc,
+				Computed:    true,
+			},
+			distributionField: schema.StringAttribute{
+				Description: distributionFieldDesc,
+				Computed:    true,
+			},
+			versionField: schema.StringAttribute{
+				Description: versionFieldDesc,
+				Computed:    true,
+			},
+			healthStatusField: schema.StringAttribute{
+				Description: healthStatusFieldDesc,
+				Computed:    true,
+			},
+			numberOfNodesField: schema.Int64Attribute{
+				Description: numberOfNodesFieldDesc,
+				Computed:    true,
+			},
+			indexExistsField: schema.BoolAttribute{
+				Description: indexExistsFieldDesc,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (c *clusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS cluster datasource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	c.backendClient = openSearchClientOf(req.ProviderData, clusterDataSourceTypeName, &resp.Diagnostics)
+
+}
+
+func (c *clusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var clusterState ClusterDataSourceModel
+
+	infoRequest := opensearchapi.InfoRequest{}
+	infoResponse, err := infoRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while retrieving cluster info",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	defer infoResponse.Body.Close()
+
+	backendInfo := &BackendInfoResponse{}
+	err = decodeBackendResponse(infoResponse.StatusCode, infoResponse.Body, backendInfo)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while retrieving cluster info",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	healthRequest := opensearchapi.ClusterHealthRequest{}
+	healthResponse, err := healthRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while retrieving cluster health",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	defer healthResponse.Body.Close()
+
+	clusterHealth := &BackendClusterHealthResponse{}
+	err = decodeBackendResponse(healthResponse.StatusCode, healthResponse.Body, clusterHealth)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while retrieving cluster health",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	existsRequest := opensearchapi.IndicesExistsRequest{
+		Index: []string{backendIndex},
+	}
+	existsResponse, err := existsRequest.Do(ctx, c.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while checking the character index",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	defer existsResponse.Body.Close()
+
+	// Only a missing index means it does not exist, other statuses
+	// such as a denied access are reported
+	indexExists := existsResponse.StatusCode != http.StatusNotFound
+	if indexExists {
+		err = decodeBackendResponse(existsResponse.StatusCode, existsResponse.Body, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error while checking the character index",
+				"Reason: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	distribution := backendInfo.Version.Distribution
+	if distribution == "" {
+		// Elasticsearch does not report its distribution
+		distribution = distributionElasticsearch
+	}
+
+	clusterState.ID = types.StringValue(backendInfo.ClusterUUID)
+	clusterState.ClusterName = types.StringValue(backendInfo.ClusterName)
+	clusterState.Distribution = types.StringValue(distribution)
+	clusterState.Version = types.StringValue(backendInfo.Version.Number)
+	clusterState.Status = types.StringValue(clusterHealth.Status)
+	clusterState.NumberOfNodes = types.Int64Value(clusterHealth.NumberOfNodes)
+	clusterState.IndexExists = types.BoolValue(indexExists)
+
+	diags := resp.State.Set(ctx, &clusterState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
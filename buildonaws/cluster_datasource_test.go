@@ -0,0 +1,136 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccClusterDataSource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	data "buildonaws_cluster" "backend" {
+	}`
+
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${backend_address}", backendContainer.Address)
+
+	dataSourceName := "data.buildonaws_cluster.backend"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: terrformConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify if the cluster details match with the Docker Compose file
+					resource.TestCheckResourceAttr(dataSourceName, clusterNameField, environment["cluster.name"]),
+					resource.TestCheckResourceAttr(dataSourceName, distributionField, "opensearch"),
+					resource.TestCheckResourceAttr(dataSourceName, numberOfNodesField, "1"),
+					resource.TestMatchResourceAttr(dataSourceName, versionField, regexp.MustCompile(`^\d+\.\d+\.\d+`)),
+					resource.TestMatchResourceAttr(dataSourceName, healthStatusField, regexp.MustCompile("^(green|yellow|red)$")),
+					resource.TestCheckResourceAttrSet(dataSourceName, indexExistsField),
+				),
+			},
+		},
+	})
+
+}
+
+func TestClusterDataSourceBackendStatuses(t *testing.T) {
+
+	testCases := map[string]struct {
+		infoStatus   int
+		healthStatus int
+		indexStatus  int
+		indexExists  bool
+		err          string
+	}{
+		"index found":     {infoStatus: http.StatusOK, healthStatus: http.StatusOK, indexStatus: http.StatusOK, indexExists: true},
+		"index missing":   {infoStatus: http.StatusOK, healthStatus: http.StatusOK, indexStatus: http.StatusNotFound},
+		"index denied":    {infoStatus: http.StatusOK, healthStatus: http.StatusOK, indexStatus: http.StatusForbidden, err: "Error while checking the character index"},
+		"health denied":   {infoStatus: http.StatusOK, healthStatus: http.StatusForbidden, err: "Error while retrieving cluster health"},
+		"info denied":     {infoStatus: http.StatusForbidden, err: "Error while retrieving cluster info"},
+		"info unexpected": {infoStatus: http.StatusInternalServerError, err: "Error while retrieving cluster info"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/":
+					w.WriteHeader(testCase.infoStatus)
+					w.Write([]byte(openSearch2InfoResponse))
+				case "/_cluster/health":
+					w.WriteHeader(testCase.healthStatus)
+					w.Write([]byte(`{"status": "green", "number_of_nodes": 1}`))
+				default:
+					w.WriteHeader(testCase.indexStatus)
+				}
+			}))
+			defer backend.Close()
+
+			server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+				backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+				connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+			})
+
+			typeName := providerTypeName + clusterDataSourceTypeName
+			dataSourceSchema := schemaResponse.DataSourceSchemas[typeName]
+			readResponse, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+				TypeName: typeName,
+				Config:   testDynamicValue(t, dataSourceSchema, map[string]tftypes.Value{}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.err != "" {
+				diagnostics := readResponse.Diagnostics
+				if len(diagnostics) == 0 || diagnostics[0].Summary != testCase.err {
+					t.Errorf("expected the error '%s', got %+v", testCase.err, diagnostics)
+				}
+				return
+			}
+			for _, diagnostic := range readResponse.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+
+			state, err := readResponse.State.Unmarshal(dataSourceSchema.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := map[string]tftypes.Value{}
+			err = state.As(&attributes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var indexExists bool
+			err = attributes[indexExistsField].As(&indexExists)
+			if err != nil || indexExists != testCase.indexExists {
+				t.Errorf("expected the index to exist: %t, got %t, %v", testCase.indexExists, indexExists, err)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,285 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+type elasticsearchCharacterStore struct {
+	backendClient *elasticsearch.Client
+	refresh       string
+}
+
+func (s *elasticsearchCharacterStore) CreateCharacter(ctx context.Context, character *ComicCharacter) (string, error) {
+
+	bodyContent, err := json.Marshal(character)
+	if err != nil {
+		return "", err
+	}
+
+	indexRequest := esapi.IndexRequest{
+		Index:   backendIndex,
+		Body:    bytes.NewReader(bodyContent),
+		Refresh: s.refresh,
+	}
+
+	indexResponse, err := indexRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return "", err
+	}
+	defer indexResponse.Body.Close()
+
+	backendResponse := &BackendResponse{}
+	err = decodeBackendResponse(indexResponse.StatusCode, indexResponse.Body, backendResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return backendResponse.ID, nil
+
+}
+
+func (s *elasticsearchCharacterStore) ReadCharacter(ctx context.Context, documentID string) (*ComicCharacter, error) {
+
+	getRequest := esapi.GetRequest{
+		Index:      backendIndex,
+		DocumentID: documentID,
+	}
+
+	getResponse, err := getRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer getResponse.Body.Close()
+
+	if getResponse.StatusCode == http.StatusNotFound {
+		return nil, errCharacterNotFound
+	}
+
+	backendResponse := &BackendResponse{}
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, backendResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	backendResponse.Source.ID = backendResponse.ID
+	return backendResponse.Source, nil
+
+}
+
+func (s *elasticsearchCharacterStore) UpdateCharacter(ctx context.Context, documentID string, character *ComicCharacter) error {
+
+	updateBody := &struct {
+		Doc *ComicCharacter `json:"doc,omitempty"`
+	}{
+		Doc: character,
+	}
+
+	bodyContent, err := json.Marshal(updateBody)
+	if err != nil {
+		return err
+	}
+
+	updateRequest := esapi.UpdateRequest{
+		Index:      backendIndex,
+		DocumentID: documentID,
+		Body:       bytes.NewReader(bodyContent),
+		Refresh:    s.refresh,
+	}
+
+	updateResponse, err := updateRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return err
+	}
+	defer updateResponse.Body.Close()
+
+	return decodeBackendResponse(updateResponse.StatusCode, updateResponse.Body, nil)
+
+}
+
+func (s *elasticsearchCharacterStore) DeleteCharacter(ctx context.Context, documentID string) error {
+
+	deleteRequest := esapi.DeleteRequest{
+		Index:      backendIndex,
+		DocumentID: documentID,
+		Refresh:    s.refresh,
+	}
+
+	deleteResponse, err := deleteRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return err
+	}
+	defer deleteResponse.Body.Close()
+
+	if deleteResponse.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return decodeBackendResponse(deleteResponse.StatusCode, deleteResponse.Body, nil)
+
+}
+
+func (s *elasticsearchCharacterStore) FindCharacterByIdentity(ctx context.Context, identity string) (*ComicCharacter, error) {
+
+	backendSearchResponse, err := s.search(ctx, identitySearchBody(identity), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstCharacterHit(backendSearchResponse)
+
+}
+
+func (s *elasticsearchCharacterStore) FindCharactersByFullName(ctx context.Context, fullName string, universe string) ([]*ComicCharacter, error) {
+
+	backendSearchResponse, err := s.search(ctx, fullNameSearchBody(fullName), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return characterHitsWithFullName(backendSearchResponse, fullName, universe), nil
+
+}
+
+// ListCharacters scrolls through the characters, as paging with from and
+// size stops at the max_result_window of the index.
+func (s *elasticsearchCharacterStore) ListCharacters(ctx context.Context, filter characterFilter, size int, page func([]*ComicCharacter) bool) error {
+
+	backendSearchResponse, err := s.search(ctx, listSearchBody(filter, size), listScrollKeepAlive)
+	if err != nil {
+		return err
+	}
+
+	scrollID := backendSearchResponse.ScrollID
+	defer func() {
+		s.clearScroll(ctx, scrollID)
+	}()
+
+	for nextCharacterPage(backendSearchResponse, size, page) {
+		backendSearchResponse, err = s.scroll(ctx, scrollID)
+		if err != nil {
+			return err
+		}
+		scrollID = backendSearchResponse.ScrollID
+	}
+
+	return nil
+
+}
+
+// search runs the query against the characters index, which may not exist
+// yet when no character has been stored. A scroll is opened when its keep
+// alive is not zero.
+func (s *elasticsearchCharacterStore) search(ctx context.Context, searchBody interface{}, scroll time.Duration) (*BackendSearchResponse, error) {
+
+	bodyContent, err := json.Marshal(searchBody)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreUnavailable := true
+	searchRequest := esapi.SearchRequest{
+		Index:             []string{backendIndex},
+		Body:              bytes.NewReader(bodyContent),
+		IgnoreUnavailable: &ignoreUnavailable,
+		Scroll:            scroll,
+	}
+
+	searchResponse, err := searchRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer searchResponse.Body.Close()
+
+	backendSearchResponse := &BackendSearchResponse{}
+	err = decodeBackendResponse(searchResponse.StatusCode, searchResponse.Body, backendSearchResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return backendSearchResponse, nil
+
+}
+
+// scroll reads the next page of a scroll opened by search.
+func (s *elasticsearchCharacterStore) scroll(ctx context.Context, scrollID string) (*BackendSearchResponse, error) {
+
+	// Sent in the body, as scroll identifiers can be too long for the URL
+	bodyContent, err := json.Marshal(scrollBody(scrollID))
+	if err != nil {
+		return nil, err
+	}
+
+	scrollRequest := esapi.ScrollRequest{
+		Body:   bytes.NewReader(bodyContent),
+		Scroll: listScrollKeepAlive,
+	}
+
+	scrollResponse, err := scrollRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer scrollResponse.Body.Close()
+
+	backendSearchResponse := &BackendSearchResponse{}
+	err = decodeBackendResponse(scrollResponse.StatusCode, scrollResponse.Body, backendSearchResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return backendSearchResponse, nil
+
+}
+
+// clearScroll releases the resources held by a scroll. Failures are
+// ignored, as the scroll expires anyway once its keep alive is over.
+func (s *elasticsearchCharacterStore) clearScroll(ctx context.Context, scrollID string) {
+
+	if scrollID == "" {
+		return
+	}
+
+	bodyContent, err := json.Marshal(clearScrollBody(scrollID))
+	if err != nil {
+		return
+	}
+
+	clearScrollRequest := esapi.ClearScrollRequest{
+		Body: bytes.NewReader(bodyContent),
+	}
+
+	clearScrollResponse, err := clearScrollRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return
+	}
+	clearScrollResponse.Body.Close()
+
+}
+
+func (s *elasticsearchCharacterStore) CharacterTypeExists(ctx context.Context, characterType string) (bool, error) {
+
+	getRequest := esapi.GetRequest{
+		Index:      characterTypeIndex,
+		DocumentID: characterType,
+	}
+
+	getResponse, err := getRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		return false, err
+	}
+	defer getResponse.Body.Close()
+
+	if getResponse.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, nil)
+	return err == nil, err
+
+}
@@ -0,0 +1,485 @@
+package buildonaws
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	fileDocumentExtension = ".json"
+	fileLockName          = ".buildonaws.lock"
+)
+
+// fileCharacterStore keeps characters as JSON documents on the local
+// file system, which is handy when running OpenSearch is not an option.
+// When the path ends with '.json' all documents live in that single file,
+// grouped by index. Otherwise the path is a directory holding one folder
+// per index and one file per document. Every operation holds a lock on a
+// sibling lock file, so parallel Terraform runs do not corrupt the data.
+type fileCharacterStore struct {
+	path string
+}
+
+func newFileCharacterStore(path string) (*fileCharacterStore, error) {
+
+	if path == "" {
+		return nil, errors.New("the path where characters are stored must be set")
+	}
+
+	store := &fileCharacterStore{path: filepath.Clean(path)}
+	directory := store.path
+	if store.singleFile() {
+		directory = filepath.Dir(store.path)
+	}
+
+	err := os.MkdirAll(directory, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating the directory '%s': %w", directory, err)
+	}
+
+	return store, nil
+
+}
+
+func (s *fileCharacterStore) CreateCharacter(ctx context.Context, character *ComicCharacter) (string, error) {
+
+	documentID, err := newDocumentID()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.withLock(true, func() error {
+		return s.writeDocument(backendIndex, documentID, character)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return documentID, nil
+
+}
+
+func (s *fileCharacterStore) ReadCharacter(ctx context.Context, documentID string) (*ComicCharacter, error) {
+
+	character := &ComicCharacter{}
+	err := s.withLock(false, func() error {
+		return s.readDocument(backendIndex, documentID, character)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errCharacterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	character.ID = documentID
+	return character, nil
+
+}
+
+func (s *fileCharacterStore) UpdateCharacter(ctx context.Context, documentID string, character *ComicCharacter) error {
+
+	return s.withLock(true, func() error {
+
+		storedCharacter := &ComicCharacter{}
+		err := s.readDocument(backendIndex, documentID, storedCharacter)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Same error type as the one reported by the search engines
+			return fmt.Errorf("document_missing_exception: the document '%s' does not exist", documentID)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Fields left empty keep their stored value, like partial updates do
+		bodyContent, err := json.Marshal(character)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(bodyContent, storedCharacter)
+		if err != nil {
+			return err
+		}
+
+		return s.writeDocument(backendIndex, documentID, storedCharacter)
+
+	})
+
+}
+
+func (s *fileCharacterStore) DeleteCharacter(ctx context.Context, documentID string) error {
+
+	return s.withLock(true, func() error {
+		return s.deleteDocument(backendIndex, documentID)
+	})
+
+}
+
+// FindCharacterByIdentity compares identities ignoring case, much like
+// the match query used by the search engines does for single words.
+func (s *fileCharacterStore) FindCharacterByIdentity(ctx context.Context, identity string) (*ComicCharacter, error) {
+
+	var documents map[string]json.RawMessage
+	err := s.withLock(false, func() error {
+		var err error
+		documents, err = s.readDocuments(backendIndex)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorted so the same character is returned when identities collide
+	documentIDs := make([]string, 0, len(documents))
+	for documentID := range documents {
+		documentIDs = append(documentIDs, documentID)
+	}
+	sort.Strings(documentIDs)
+
+	for _, documentID := range documentIDs {
+		character := &ComicCharacter{}
+		err = json.Unmarshal(documents[documentID], character)
+		if err != nil {
+			return nil, fmt.Errorf("error while unmarshalling the document '%s': %w", documentID, err)
+		}
+		if strings.EqualFold(character.Identity, identity) {
+			character.ID = documentID
+			return character, nil
+		}
+	}
+
+	return nil, errCharacterNotFound
+
+}
+
+func (s *fileCharacterStore) FindCharactersByFullName(ctx context.Context, fullName string, universe string) ([]*ComicCharacter, error) {
+
+	var documents map[string]json.RawMessage
+	err := s.withLock(false, func() error {
+		var err error
+		documents, err = s.readDocuments(backendIndex)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	characters := []*ComicCharacter{}
+	for documentID, document := range documents {
+		character := &ComicCharacter{}
+		err = json.Unmarshal(document, character)
+		if err != nil {
+			return nil, fmt.Errorf("error while unmarshalling the document '%s': %w", documentID, err)
+		}
+		if hasFullName(character, fullName, universe) {
+			character.ID = documentID
+			characters = append(characters, character)
+		}
+	}
+
+	return characters, nil
+
+}
+
+// ListCharacters filters the documents before paging through them, as
+// there is no loose matching to make up for afterwards.
+func (s *fileCharacterStore) ListCharacters(ctx context.Context, filter characterFilter, size int, page func([]*ComicCharacter) bool) error {
+
+	var documents map[string]json.RawMessage
+	err := s.withLock(false, func() error {
+		var err error
+		documents, err = s.readDocuments(backendIndex)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sorted so pages do not overlap
+	documentIDs := make([]string, 0, len(documents))
+	for documentID := range documents {
+		documentIDs = append(documentIDs, documentID)
+	}
+	sort.Strings(documentIDs)
+
+	characters := []*ComicCharacter{}
+	for _, documentID := range documentIDs {
+		character := &ComicCharacter{}
+		err = json.Unmarshal(documents[documentID], character)
+		if err != nil {
+			return fmt.Errorf("error while unmarshalling the document '%s': %w", documentID, err)
+		}
+		if filter.matches(character) {
+			character.ID = documentID
+			characters = append(characters, character)
+		}
+	}
+
+	for from := 0; from < len(characters); from += size {
+		if !page(characters[from:min(from+size, len(characters))]) {
+			break
+		}
+	}
+
+	return nil
+
+}
+
+func (s *fileCharacterStore) CharacterTypeExists(ctx context.Context, characterType string) (bool, error) {
+
+	err := s.withLock(false, func() error {
+		return s.readDocument(characterTypeIndex, characterType, &ComicCharacterType{})
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+
+}
+
+func (s *fileCharacterStore) singleFile() bool {
+	return strings.EqualFold(filepath.Ext(s.path), fileDocumentExtension)
+}
+
+func (s *fileCharacterStore) withLock(exclusive bool, operation func() error) error {
+
+	lockPath := filepath.Join(s.path, fileLockName)
+	if s.singleFile() {
+		lockPath = s.path + ".lock"
+	}
+
+	lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("error while opening the lock file '%s': %w", lockPath, err)
+	}
+	defer lockFile.Close()
+
+	err = lockFileHandle(lockFile, exclusive)
+	if err != nil {
+		return fmt.Errorf("error while locking the file '%s': %w", lockPath, err)
+	}
+	defer unlockFileHandle(lockFile)
+
+	return operation()
+
+}
+
+func (s *fileCharacterStore) documentPath(index, documentID string) string {
+	return filepath.Join(s.path, index, documentID+fileDocumentExtension)
+}
+
+func (s *fileCharacterStore) readDocuments(index string) (map[string]json.RawMessage, error) {
+
+	if s.singleFile() {
+		indexes, err := s.readSingleFile()
+		if err != nil {
+			return nil, err
+		}
+		return indexes[index], nil
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.path, index))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make(map[string]json.RawMessage, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fileDocumentExtension {
+			continue
+		}
+		documentID := strings.TrimSuffix(entry.Name(), fileDocumentExtension)
+		documents[documentID], err = os.ReadFile(s.documentPath(index, documentID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+
+}
+
+// readDocument reports fs.ErrNotExist when there is no such document,
+// in both the directory and the single file layouts.
+func (s *fileCharacterStore) readDocument(index, documentID string, target interface{}) error {
+
+	var bodyContent []byte
+	if s.singleFile() {
+		indexes, err := s.readSingleFile()
+		if err != nil {
+			return err
+		}
+		document, found := indexes[index][documentID]
+		if !found {
+			return fs.ErrNotExist
+		}
+		bodyContent = document
+	} else {
+		if !validDocumentID(documentID) {
+			return fs.ErrNotExist
+		}
+		var err error
+		bodyContent, err = os.ReadFile(s.documentPath(index, documentID))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := json.Unmarshal(bodyContent, target)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling the document '%s': %w", documentID, err)
+	}
+
+	return nil
+
+}
+
+func (s *fileCharacterStore) writeDocument(index, documentID string, document interface{}) error {
+
+	bodyContent, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if s.singleFile() {
+		indexes, err := s.readSingleFile()
+		if err != nil {
+			return err
+		}
+		if indexes[index] == nil {
+			indexes[index] = map[string]json.RawMessage{}
+		}
+		indexes[index][documentID] = bodyContent
+		return s.writeSingleFile(indexes)
+	}
+
+	if !validDocumentID(documentID) {
+		return fmt.Errorf("invalid document identifier '%s'", documentID)
+	}
+
+	err = os.MkdirAll(filepath.Join(s.path, index), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(s.documentPath(index, documentID), bodyContent)
+
+}
+
+func (s *fileCharacterStore) deleteDocument(index, documentID string) error {
+
+	if s.singleFile() {
+		indexes, err := s.readSingleFile()
+		if err != nil {
+			return err
+		}
+		if _, found := indexes[index][documentID]; !found {
+			return nil
+		}
+		delete(indexes[index], documentID)
+		return s.writeSingleFile(indexes)
+	}
+
+	if !validDocumentID(documentID) {
+		return nil
+	}
+
+	err := os.Remove(s.documentPath(index, documentID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+
+}
+
+func (s *fileCharacterStore) readSingleFile() (map[string]map[string]json.RawMessage, error) {
+
+	indexes := map[string]map[string]json.RawMessage{}
+
+	bodyContent, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && len(bodyContent) == 0) {
+		return indexes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(bodyContent, &indexes)
+	if err != nil {
+		return nil, fmt.Errorf("error while unmarshalling the file '%s': %w", s.path, err)
+	}
+
+	return indexes, nil
+
+}
+
+func (s *fileCharacterStore) writeSingleFile(indexes map[string]map[string]json.RawMessage) error {
+
+	bodyContent, err := json.MarshalIndent(indexes, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(s.path, bodyContent)
+
+}
+
+// writeFileAtomically writes into a temporary file that is then renamed,
+// so readers never see a partially written document.
+func writeFileAtomically(path string, content []byte) error {
+
+	temporaryFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	_, err = temporaryFile.Write(content)
+	if err == nil {
+		err = temporaryFile.Sync()
+	}
+	if closeErr := temporaryFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryFile.Name(), path)
+
+}
+
+// newDocumentID generates 20 characters long identifiers, just like the
+// ones generated by OpenSearch, but lowercase as they are used as file
+// names on file systems that may be case insensitive.
+func newDocumentID() (string, error) {
+
+	randomBytes := make([]byte, 10)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+
+}
+
+// validDocumentID prevents identifiers from escaping the index directory.
+func validDocumentID(documentID string) bool {
+	return documentID != "" && documentID != "." && documentID != ".." &&
+		!strings.ContainsAny(documentID, `/\`)
+}
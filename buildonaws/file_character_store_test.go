@@ -0,0 +1,78 @@
+package buildonaws
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestFileCharacterStore(t *testing.T) {
+
+	layouts := map[string]string{
+		"directory":   "characters",
+		"single-file": "characters.json",
+	}
+
+	for layout, path := range layouts {
+		t.Run(layout, func(t *testing.T) {
+
+			store, err := newFileCharacterStore(filepath.Join(t.TempDir(), path))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = store.writeDocument(characterTypeIndex, "sidekick", &ComicCharacterType{Name: "sidekick"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testCharacterStore(t, store)
+
+		})
+	}
+
+}
+
+func TestFileCharacterStoreConcurrentWrites(t *testing.T) {
+
+	ctx := context.Background()
+	store, err := newFileCharacterStore(filepath.Join(t.TempDir(), "characters.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const writers = 20
+	var waitGroup sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+			// Each writer uses its own store, just like parallel Terraform runs
+			writerStore := &fileCharacterStore{path: store.path}
+			_, err := writerStore.CreateCharacter(ctx, &ComicCharacter{
+				FullName: "Character " + strconv.Itoa(i),
+				Identity: "Identity " + strconv.Itoa(i),
+			})
+			if err != nil {
+				t.Errorf("create: %v", err)
+			}
+		}(i)
+	}
+	waitGroup.Wait()
+
+	documents, err := store.readDocuments(backendIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(documents) != writers {
+		t.Errorf("expected %d characters, got %d", writers, len(documents))
+	}
+
+	character, err := store.FindCharacterByIdentity(ctx, "identity 7")
+	if err != nil || character.FullName != "Character 7" {
+		t.Errorf("find: unexpected character %+v, error: %v", character, err)
+	}
+
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package buildonaws
+
+import (
+	"os"
+	"syscall"
+)
+
+func lockFileHandle(file *os.File, exclusive bool) error {
+
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	return syscall.Flock(int(file.Fd()), how)
+
+}
+
+func unlockFileHandle(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,196 @@
+package buildonaws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &characterSlugFunction{}
+	_ function.Function = &isValidTypeFunction{}
+	_ function.Function = &parseImportIDFunction{}
+)
+
+func NewCharacterSlugFunction() function.Function {
+	return &characterSlugFunction{}
+}
+
+type characterSlugFunction struct{}
+
+func (f *characterSlugFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = characterSlugFunctionName
+}
+
+func (f *characterSlugFunction) Definition(_ context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     characterSlugFunctionSummary,
+		Description: characterSlugFunctionDesc,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        fullNameField,
+				Description: fullNameParameterDesc,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *characterSlugFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var fullName string
+	resp.Error = req.Arguments.Get(ctx, &fullName)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, characterSlug(fullName))
+
+}
+
+func NewIsValidTypeFunction() function.Function {
+	return &isValidTypeFunction{}
+}
+
+type isValidTypeFunction struct{}
+
+func (f *isValidTypeFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = isValidTypeFunctionName
+}
+
+func (f *isValidTypeFunction) Definition(_ context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     isValidTypeFunctionSummary,
+		Description: isValidTypeFunctionDesc,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        typeField,
+				Description: typeParameterDesc,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidTypeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var characterType string
+	resp.Error = req.Arguments.Get(ctx, &characterType)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isBuiltInCharacterType(characterType))
+
+}
+
+func NewParseImportIDFunction() function.Function {
+	return &parseImportIDFunction{}
+}
+
+type parseImportIDFunction struct{}
+
+func (f *parseImportIDFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = parseImportIDFunctionName
+}
+
+func (f *parseImportIDFunction) Definition(_ context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     parseImportIDFunctionSummary,
+		Description: parseImportIDFunctionDesc,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        idField,
+				Description: importIDParameterDesc,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: importIDAttributeTypes,
+		},
+	}
+}
+
+var importIDAttributeTypes = map[string]attr.Type{
+	indexField:      types.StringType,
+	documentIDField: types.StringType,
+}
+
+func (f *parseImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+
+	var importID string
+	resp.Error = req.Arguments.Get(ctx, &importID)
+	if resp.Error != nil {
+		return
+	}
+
+	index, documentID, err := parseImportID(importID, backendIndex)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(importIDAttributeTypes, map[string]attr.Value{
+		indexField:      types.StringValue(index),
+		documentIDField: types.StringValue(documentID),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+
+}
+
+// characterSlug keeps letters and digits from any script, so
+// fullnames such as 'Ms. Marvel' and 'Hawkeye' get readable slugs.
+func characterSlug(fullName string) string {
+
+	var slug strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(fullName) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = slug.Len() > 0
+			continue
+		}
+		if separate {
+			slug.WriteString("-")
+			separate = false
+		}
+		slug.WriteRune(r)
+	}
+
+	return slug.String()
+
+}
+
+// parseImportID splits identifiers of the form '[<index>/]<document_id>',
+// where the index must be one where the provider stores documents. The
+// default index is returned when the identifier has no index.
+func parseImportID(importID string, defaultIndex string) (string, string, error) {
+
+	index, documentID, found := strings.Cut(importID, importIDSeparator)
+	if !found {
+		index, documentID = defaultIndex, importID
+	}
+
+	if strings.TrimSpace(documentID) == "" {
+		return "", "", errors.New("the import identifier must contain the identifier of a document")
+	}
+
+	for _, importIndex := range importIndexes {
+		if index == importIndex {
+			return index, documentID, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("the index '%s' is not one where documents are stored, expected one of '%s'",
+		index, strings.Join(importIndexes, ","))
+
+}
@@ -0,0 +1,124 @@
+package buildonaws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls the function the way Terraform does, with a single
+// string argument, returning the result along with any error raised.
+func runFunction(t *testing.T, f function.Function, argument string) (attr.Value, *function.FuncError) {
+
+	ctx := context.Background()
+
+	definitionResponse := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResponse)
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("unexpected definition diagnostics: %v", definitionResponse.Diagnostics)
+	}
+
+	resultData, err := definitionResponse.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatalf("unexpected result data error: %v", err)
+	}
+
+	runResponse := &function.RunResponse{Result: resultData}
+	f.Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(argument)}),
+	}, runResponse)
+
+	return runResponse.Result.Value(), runResponse.Error
+
+}
+
+func TestCharacterSlugFunction(t *testing.T) {
+
+	testCases := map[string]string{
+		"Spider-Man":             "spider-man",
+		"Ms. Marvel":             "ms-marvel",
+		"  Daredevil  ":          "daredevil",
+		"Doctor Strange!!":       "doctor-strange",
+		"Namor, the Sub-Mariner": "namor-the-sub-mariner",
+		"Élodie 2099":            "élodie-2099",
+		"":                       "",
+	}
+
+	for fullName, expected := range testCases {
+		result, err := runFunction(t, NewCharacterSlugFunction(), fullName)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", fullName, err)
+		}
+		if !result.Equal(types.StringValue(expected)) {
+			t.Errorf("%s: expected slug '%s', got %s", fullName, expected, result)
+		}
+	}
+
+}
+
+func TestIsValidTypeFunction(t *testing.T) {
+
+	testCases := map[string]bool{
+		"hero":      true,
+		"anti-hero": true,
+		"villain":   true,
+		"Hero":      false,
+		"sidekick":  false,
+		"":          false,
+	}
+
+	for characterType, expected := range testCases {
+		result, err := runFunction(t, NewIsValidTypeFunction(), characterType)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", characterType, err)
+		}
+		if !result.Equal(types.BoolValue(expected)) {
+			t.Errorf("%s: expected %v, got %s", characterType, expected, result)
+		}
+	}
+
+}
+
+func TestParseImportIDFunction(t *testing.T) {
+
+	testCases := map[string]struct {
+		index      string
+		documentID string
+		err        string
+	}{
+		"g3UvQIoBdJ6Qq1Gm2Zkh":            {index: backendIndex, documentID: "g3UvQIoBdJ6Qq1Gm2Zkh"},
+		"buildonaws/g3UvQIoBdJ6Qq1Gm2Zkh": {index: backendIndex, documentID: "g3UvQIoBdJ6Qq1Gm2Zkh"},
+		"buildonaws_types/sidekick":       {index: characterTypeIndex, documentID: "sidekick"},
+		"buildonaws_meta/migrations":      {err: "not one where documents are stored"},
+		"buildonaws/":                     {err: "must contain the identifier of a document"},
+		"":                                {err: "must contain the identifier of a document"},
+	}
+
+	for importID, testCase := range testCases {
+
+		result, err := runFunction(t, NewParseImportIDFunction(), importID)
+		if testCase.err != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("'%s': expected an error containing '%s', got: %v", importID, testCase.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("'%s': unexpected error: %v", importID, err)
+		}
+
+		expected := types.ObjectValueMust(importIDAttributeTypes, map[string]attr.Value{
+			indexField:      types.StringValue(testCase.index),
+			documentIDField: types.StringValue(testCase.documentID),
+		})
+		if !result.Equal(expected) {
+			t.Errorf("'%s': expected %s, got %s", importID, expected, result)
+		}
+
+	}
+
+}
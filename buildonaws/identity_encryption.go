@@ -0,0 +1,208 @@
+package buildonaws
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+const (
+	identityEncryptionContext = "buildonaws-identity-encryption"
+	identityBlindIndexContext = "buildonaws-identity-blind-index"
+)
+
+// encryptedCharacterStore encrypts the identity of characters before they
+// are handed over to the backend, and decrypts it when they are read back.
+// The identity field of the stored document holds a keyed hash of the
+// identity, known as a blind index, so characters can still be looked up
+// by their exact identity without the backend ever seeing it in clear.
+type encryptedCharacterStore struct {
+	characterStore
+	aead          cipher.AEAD
+	blindIndexKey []byte
+}
+
+// newEncryptedCharacterStore derives separate keys for encryption and
+// for the blind index from the given secret, so neither can be used to
+// guess the other.
+func newEncryptedCharacterStore(store characterStore, secret string) (*encryptedCharacterStore, error) {
+
+	block, err := aes.NewCipher(deriveKey(secret, identityEncryptionContext))
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedCharacterStore{
+		characterStore: store,
+		aead:           aead,
+		blindIndexKey:  deriveKey(secret, identityBlindIndexContext),
+	}, nil
+
+}
+
+func (s *encryptedCharacterStore) CreateCharacter(ctx context.Context, character *ComicCharacter) (string, error) {
+
+	encryptedCharacter, err := s.encrypt(character)
+	if err != nil {
+		return "", err
+	}
+
+	return s.characterStore.CreateCharacter(ctx, encryptedCharacter)
+
+}
+
+func (s *encryptedCharacterStore) ReadCharacter(ctx context.Context, documentID string) (*ComicCharacter, error) {
+
+	character, err := s.characterStore.ReadCharacter(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decrypt(character)
+
+}
+
+func (s *encryptedCharacterStore) UpdateCharacter(ctx context.Context, documentID string, character *ComicCharacter) error {
+
+	encryptedCharacter, err := s.encrypt(character)
+	if err != nil {
+		return err
+	}
+
+	return s.characterStore.UpdateCharacter(ctx, documentID, encryptedCharacter)
+
+}
+
+// FindCharacterByIdentity falls back to the identity in clear, as the
+// characters stored before the encryption was enabled have no blind index
+// until they are updated.
+func (s *encryptedCharacterStore) FindCharacterByIdentity(ctx context.Context, identity string) (*ComicCharacter, error) {
+
+	character, err := s.characterStore.FindCharacterByIdentity(ctx, s.blindIndex(identity))
+	if err == errCharacterNotFound {
+		character, err = s.characterStore.FindCharacterByIdentity(ctx, identity)
+		if err == nil && character.IdentityEncrypted != "" {
+			return nil, errCharacterNotFound
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decrypt(character)
+
+}
+
+func (s *encryptedCharacterStore) FindCharactersByFullName(ctx context.Context, fullName string, universe string) ([]*ComicCharacter, error) {
+
+	characters, err := s.characterStore.FindCharactersByFullName(ctx, fullName, universe)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, character := range characters {
+		characters[i], err = s.decrypt(character)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return characters, nil
+
+}
+
+func (s *encryptedCharacterStore) ListCharacters(ctx context.Context, filter characterFilter, size int, page func([]*ComicCharacter) bool) error {
+
+	var decryptErr error
+	err := s.characterStore.ListCharacters(ctx, filter, size, func(characters []*ComicCharacter) bool {
+		for i, character := range characters {
+			characters[i], decryptErr = s.decrypt(character)
+			if decryptErr != nil {
+				return false
+			}
+		}
+		return page(characters)
+	})
+	if err != nil {
+		return err
+	}
+
+	return decryptErr
+
+}
+
+// encrypt returns a copy of the character, leaving the one
+// from the plan untouched as it is written to the state.
+func (s *encryptedCharacterStore) encrypt(character *ComicCharacter) (*ComicCharacter, error) {
+
+	nonce := make([]byte, s.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// The blind index binds the ciphertext to the identity it stands for
+	blindIndex := s.blindIndex(character.Identity)
+	cipherText := s.aead.Seal(nonce, nonce, []byte(character.Identity), []byte(blindIndex))
+
+	encryptedCharacter := *character
+	encryptedCharacter.Identity = blindIndex
+	encryptedCharacter.IdentityEncrypted = base64.StdEncoding.EncodeToString(cipherText)
+	return &encryptedCharacter, nil
+
+}
+
+// decrypt leaves characters stored before the encryption
+// was enabled as they are, as their identity is in clear.
+func (s *encryptedCharacterStore) decrypt(character *ComicCharacter) (*ComicCharacter, error) {
+
+	if character.IdentityEncrypted == "" {
+		return character, nil
+	}
+
+	cipherText, err := base64.StdEncoding.DecodeString(character.IdentityEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding the identity of the character '%s': %w", character.ID, err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(cipherText) < nonceSize {
+		return nil, fmt.Errorf("error while decrypting the identity of the character '%s': %w",
+			character.ID, errors.New("the encrypted identity is too short"))
+	}
+
+	identity, err := s.aead.Open(nil, cipherText[:nonceSize], cipherText[nonceSize:], []byte(character.Identity))
+	if err != nil {
+		return nil, fmt.Errorf("error while decrypting the identity of the character '%s', "+
+			"make sure the identity encryption key is the one used to store it: %w", character.ID, err)
+	}
+
+	character.Identity = string(identity)
+	character.IdentityEncrypted = ""
+	return character, nil
+
+}
+
+func (s *encryptedCharacterStore) blindIndex(identity string) string {
+	mac := hmac.New(sha256.New, s.blindIndexKey)
+	mac.Write([]byte(identity))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func deriveKey(secret string, context string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(context))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,91 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedCharacterStore(t *testing.T) {
+
+	fileStore, err := newFileCharacterStore(filepath.Join(t.TempDir(), "characters"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = fileStore.writeDocument(characterTypeIndex, "sidekick", &ComicCharacterType{Name: "sidekick"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := newEncryptedCharacterStore(fileStore, "a-secret-that-is-long-enough")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCharacterStore(t, store)
+
+}
+
+func TestEncryptedCharacterStoreAtRest(t *testing.T) {
+
+	ctx := context.Background()
+	fileStore, err := newFileCharacterStore(filepath.Join(t.TempDir(), "characters.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := newEncryptedCharacterStore(fileStore, "a-secret-that-is-long-enough")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	character := &ComicCharacter{FullName: "Daredevil", Identity: "Matt Murdock"}
+	documentID, err := store.CreateCharacter(ctx, character)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if character.Identity != "Matt Murdock" {
+		t.Errorf("expected the character given to be left untouched, got identity '%s'", character.Identity)
+	}
+
+	documents, err := fileStore.readDocuments(backendIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedDocument, _ := json.Marshal(documents[documentID])
+	if strings.Contains(string(storedDocument), "Murdock") {
+		t.Errorf("expected the identity to be encrypted at rest, got %s", storedDocument)
+	}
+
+	// Another key can neither find nor decrypt the character
+	otherStore, err := newEncryptedCharacterStore(fileStore, "another-secret-that-is-long-enough")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = otherStore.FindCharacterByIdentity(ctx, "Matt Murdock")
+	if err != errCharacterNotFound {
+		t.Errorf("find with another key: expected character not found, got: %v", err)
+	}
+	_, err = otherStore.ReadCharacter(ctx, documentID)
+	if err == nil || !strings.Contains(err.Error(), "identity encryption key") {
+		t.Errorf("read with another key: expected a decryption error, got: %v", err)
+	}
+
+	// Characters stored before the encryption was enabled are still readable
+	legacyID, err := fileStore.CreateCharacter(ctx, &ComicCharacter{FullName: "Elektra", Identity: "Elektra Natchios"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyCharacter, err := store.ReadCharacter(ctx, legacyID)
+	if err != nil || legacyCharacter.Identity != "Elektra Natchios" {
+		t.Errorf("read legacy: unexpected character %+v, error: %v", legacyCharacter, err)
+	}
+	foundCharacter, err := store.FindCharacterByIdentity(ctx, "Elektra Natchios")
+	if err != nil || foundCharacter.ID != legacyID {
+		t.Errorf("find legacy: unexpected character %+v, error: %v", foundCharacter, err)
+	}
+
+}
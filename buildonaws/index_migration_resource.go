@@ -0,0 +1,218 @@
+package buildonaws
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+)
+
+var (
+	_ resource.Resource                = &indexMigrationResource{}
+	_ resource.ResourceWithConfigure   = &indexMigrationResource{}
+	_ resource.ResourceWithImportState = &indexMigrationResource{}
+)
+
+func NewIndexMigrationResource() resource.Resource {
+	return &indexMigrationResource{}
+}
+
+type indexMigrationResource struct {
+	backendClient *opensearch.Client
+}
+
+func (i *indexMigrationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + indexMigrationResourceTypeName
+}
+
+func (i *indexMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Description: indexMigrationIDFieldDesc,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			targetVersionField: schema.Int64Attribute{
+				Description: targetVersionFieldDesc,
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(latestIndexMigrationVersion()),
+				Validators: []validator.Int64{
+					int64validator.Between(0, latestIndexMigrationVersion()),
+				},
+			},
+			latestVersionField: schema.Int64Attribute{
+				Description: latestVersionFieldDesc,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (i *indexMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS index migration resource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	i.backendClient = openSearchClientOf(req.ProviderData, indexMigrationResourceTypeName, &resp.Diagnostics)
+
+}
+
+func (i *indexMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root(idField), req, resp)
+}
+
+func (i *indexMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var indexMigrationPlan IndexMigrationResourceModel
+	diags := req.Plan.Get(ctx, &indexMigrationPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	i.migrate(ctx, &indexMigrationPlan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, indexMigrationPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (i *indexMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+
+	var indexMigrationState IndexMigrationResourceModel
+	diags := req.State.Get(ctx, &indexMigrationState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appliedVersion, err := readIndexMigrationVersion(ctx, i.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading index migrations",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	// A lower version in the backend shows up as a change to apply again.
+	// A higher one, applied from another workspace or by a newer provider,
+	// cannot be reverted, so the target is kept to avoid a perpetual diff.
+	// Imported migrations have no target yet, so they take the applied one.
+	targetVersion := indexMigrationState.TargetVersion
+	if targetVersion.IsNull() || appliedVersion < targetVersion.ValueInt64() {
+		indexMigrationState.TargetVersion = types.Int64Value(appliedVersion)
+	} else if appliedVersion > targetVersion.ValueInt64() {
+		addIndexAheadWarning(&resp.Diagnostics, appliedVersion, targetVersion.ValueInt64())
+	}
+	indexMigrationState.ID = types.StringValue(indexMigrationDocumentID)
+	indexMigrationState.LatestVersion = types.Int64Value(latestIndexMigrationVersion())
+
+	diags = resp.State.Set(ctx, &indexMigrationState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func (i *indexMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+
+	var indexMigrationPlan IndexMigrationResourceModel
+	diags := req.Plan.Get(ctx, &indexMigrationPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	i.migrate(ctx, &indexMigrationPlan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, indexMigrationPlan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+// Delete leaves the documents as they are, since migrations only move
+// documents forward and older versions of the provider can read them.
+func (i *indexMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+
+	resp.Diagnostics.AddWarning(
+		"Index migrations are not reverted",
+		"The migrations applied to the character index are kept, only the resource is removed from the state.",
+	)
+
+}
+
+func (i *indexMigrationResource) migrate(ctx context.Context, indexMigrationPlan *IndexMigrationResourceModel, diags *diag.Diagnostics) {
+
+	appliedVersion, err := readIndexMigrationVersion(ctx, i.backendClient)
+	if err != nil {
+		diags.AddError(
+			"Error while reading index migrations",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	targetVersion := indexMigrationPlan.TargetVersion.ValueInt64()
+	if targetVersion < appliedVersion {
+		addIndexAheadWarning(diags, appliedVersion, targetVersion)
+	} else {
+		err = applyIndexMigrations(ctx, i.backendClient, appliedVersion, targetVersion)
+		if err != nil {
+			diags.AddError(
+				"Error while applying index migrations",
+				"Reason: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	indexMigrationPlan.ID = types.StringValue(indexMigrationDocumentID)
+	indexMigrationPlan.LatestVersion = types.Int64Value(latestIndexMigrationVersion())
+
+}
+
+func addIndexAheadWarning(diags *diag.Diagnostics, appliedVersion int64, targetVersion int64) {
+	diags.AddAttributeWarning(
+		path.Root(targetVersionField),
+		"Index migrations cannot be reverted",
+		"The character index is already at version "+strconv.FormatInt(appliedVersion, 10)+
+			", which is after the target version "+strconv.FormatInt(targetVersion, 10)+
+			". The migrations after the target are kept.",
+	)
+}
@@ -0,0 +1,239 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+func TestIndexMigrationsAreSequential(t *testing.T) {
+
+	for i, migration := range indexMigrations {
+		if migration.Version != int64(i+1) {
+			t.Errorf("expected migration '%s' to have version %d, got %d", migration.Description, i+1, migration.Version)
+		}
+	}
+
+}
+
+func TestAccIndexMigrationResource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Stored as older versions of the provider did, without tags or timestamps
+	err = createCharacter(ctx, &ComicCharacter{
+		FullName: "Daredevil",
+		Identity: "Matt Murdock",
+		KnownAs:  "The man without fear",
+		Type:     "hero",
+	}, backendContainer)
+	if err != nil {
+		t.Error(err)
+	}
+
+	terraformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	resource "buildonaws_index_migration" "characters" {
+		${target_version}
+	}`
+
+	terraformConfig = strings.ReplaceAll(terraformConfig, "${backend_address}", backendContainer.Address)
+
+	tfConfigFirstVersionTest := strings.ReplaceAll(terraformConfig, "${target_version}", "target_version = 1")
+	tfConfigLatestVersionTest := strings.ReplaceAll(terraformConfig, "${target_version}", "")
+
+	resourceName := "buildonaws_index_migration.characters"
+	latestVersion := strconv.FormatInt(latestIndexMigrationVersion(), 10)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, up to the first version only
+			{
+				Config: tfConfigFirstVersionTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, idField, indexMigrationDocumentID),
+					resource.TestCheckResourceAttr(resourceName, targetVersionField, "1"),
+					resource.TestCheckResourceAttr(resourceName, latestVersionField, latestVersion),
+					testCheckCharactersMissing(backendContainer, tagsField, 0),
+					testCheckCharactersMissing(backendContainer, createdAtField, 1),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, up to the latest version
+			{
+				Config: tfConfigLatestVersionTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, targetVersionField, latestVersion),
+					testCheckCharactersMissing(backendContainer, createdAtField, 0),
+				),
+			},
+			// Migrations already applied are kept, without showing up as a change
+			{
+				Config: tfConfigFirstVersionTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, targetVersionField, "1"),
+					testCheckCharactersMissing(backendContainer, createdAtField, 0),
+				),
+			},
+			{
+				Config:   tfConfigFirstVersionTest,
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+
+}
+
+func TestIndexMigrationResourceRead(t *testing.T) {
+
+	ctx := context.Background()
+
+	var appliedVersion int64
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"_id":     indexMigrationDocumentID,
+			"found":   true,
+			"_source": map[string]interface{}{"version": appliedVersion},
+		})
+	}))
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + indexMigrationResourceTypeName
+	resourceSchema := schemaResponse.ResourceSchemas[typeName]
+	latestVersion := latestIndexMigrationVersion()
+
+	testCases := map[string]struct {
+		targetVersion   interface{}
+		appliedVersion  int64
+		expectedVersion int64
+		expectWarning   bool
+	}{
+		"up to date":      {targetVersion: int64(1), appliedVersion: 1, expectedVersion: 1},
+		"behind":          {targetVersion: latestVersion, appliedVersion: 1, expectedVersion: 1},
+		"ahead":           {targetVersion: int64(1), appliedVersion: latestVersion + 1, expectedVersion: 1, expectWarning: true},
+		"imported":        {appliedVersion: 1, expectedVersion: 1},
+		"imported, ahead": {appliedVersion: latestVersion + 1, expectedVersion: latestVersion + 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			appliedVersion = testCase.appliedVersion
+			readResponse, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName: typeName,
+				CurrentState: testDynamicValue(t, resourceSchema, map[string]tftypes.Value{
+					idField:            tftypes.NewValue(tftypes.String, indexMigrationDocumentID),
+					targetVersionField: tftypes.NewValue(tftypes.Number, testCase.targetVersion),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var warnings int
+			for _, diagnostic := range readResponse.Diagnostics {
+				if diagnostic.Severity != tfprotov6.DiagnosticSeverityWarning {
+					t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+				warnings++
+			}
+			if testCase.expectWarning != (warnings > 0) {
+				t.Errorf("expected a warning: %t, got %d of them", testCase.expectWarning, warnings)
+			}
+
+			state, err := readResponse.NewState.Unmarshal(resourceSchema.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := map[string]tftypes.Value{}
+			state.As(&attributes)
+			expectedVersion := tftypes.NewValue(tftypes.Number, testCase.expectedVersion)
+			if !attributes[targetVersionField].Equal(expectedVersion) {
+				t.Errorf("expected the target version %s, got %s", expectedVersion, attributes[targetVersionField])
+			}
+
+		})
+	}
+
+}
+
+func testCheckCharactersMissing(backendContainer *backendContainer, field string, expected int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		backendClient, err := opensearch.NewClient(
+			opensearch.Config{
+				Addresses: []string{backendContainer.Address},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		bodyContent, err := json.Marshal(map[string]interface{}{
+			"query": missingFieldQuery(field),
+		})
+		if err != nil {
+			return err
+		}
+
+		countRequest := opensearchapi.CountRequest{
+			Index: []string{backendIndex},
+			Body:  bytes.NewReader(bodyContent),
+		}
+
+		countResponse, err := countRequest.Do(context.Background(), backendClient)
+		if err != nil {
+			return err
+		}
+		defer countResponse.Body.Close()
+
+		backendResponse := &struct {
+			Count int64 `json:"count"`
+		}{}
+		err = decodeBackendResponse(countResponse.StatusCode, countResponse.Body, backendResponse)
+		if err != nil {
+			return err
+		}
+
+		if backendResponse.Count != expected {
+			return fmt.Errorf("expected %d characters without %s, got %d", expected, field, backendResponse.Count)
+		}
+
+		return nil
+
+	}
+}
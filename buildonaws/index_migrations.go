@@ -0,0 +1,200 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+// indexMigration changes the documents stored in the character index,
+// so documents stored by older versions of the provider match the shape
+// of the current ComicCharacter. The script is run with update-by-query
+// against the documents matching the query, with the current time given
+// as the 'now' parameter.
+type indexMigration struct {
+	Version     int64
+	Description string
+	Query       map[string]interface{}
+	Script      string
+}
+
+// indexMigrations must be kept in order, and released migrations must never
+// be changed, as the version applied to each index is tracked in the meta
+// document. Fields are backfilled, renamed or transformed by adding a new
+// migration with the next version.
+var indexMigrations = []*indexMigration{
+	{
+		Version:     1,
+		Description: "Backfill an empty set of tags on characters stored without them",
+		Query:       missingFieldQuery(tagsField),
+		Script:      "ctx._source.tags = [];",
+	},
+	{
+		Version:     2,
+		Description: "Backfill the timestamps of characters stored without them",
+		Query:       missingFieldQuery(createdAtField),
+		Script: "ctx._source.created_at = params.now; " +
+			"if (ctx._source.updated_at == null) { ctx._source.updated_at = params.now; }",
+	},
+}
+
+func latestIndexMigrationVersion() int64 {
+	return indexMigrations[len(indexMigrations)-1].Version
+}
+
+func missingFieldQuery(field string) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must_not": map[string]interface{}{
+				"exists": map[string]interface{}{"field": field},
+			},
+		},
+	}
+}
+
+// readIndexMigrationVersion returns the version of the last migration
+// applied to the character index, which is zero when none has been.
+func readIndexMigrationVersion(ctx context.Context, backendClient *opensearch.Client) (int64, error) {
+
+	getRequest := opensearchapi.GetRequest{
+		Index:      metaIndex,
+		DocumentID: indexMigrationDocumentID,
+	}
+
+	getResponse, err := getRequest.Do(ctx, backendClient)
+	if err != nil {
+		return 0, err
+	}
+	defer getResponse.Body.Close()
+
+	if getResponse.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+
+	backendResponse := &BackendIndexMigrationResponse{}
+	err = decodeBackendResponse(getResponse.StatusCode, getResponse.Body, backendResponse)
+	if err != nil {
+		return 0, err
+	}
+
+	return backendResponse.Source.Version, nil
+
+}
+
+// applyIndexMigrations runs the migrations after the applied version, up
+// to the target version. The meta document is updated after each of them,
+// so a failure never causes a migration to be applied twice.
+func applyIndexMigrations(ctx context.Context, backendClient *opensearch.Client, appliedVersion int64, targetVersion int64) error {
+
+	for _, migration := range indexMigrations {
+
+		if migration.Version <= appliedVersion || migration.Version > targetVersion {
+			continue
+		}
+
+		updated, err := applyIndexMigration(ctx, backendClient, migration)
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+
+		tflog.Info(ctx, "Index migration applied", map[string]interface{}{
+			"version":     migration.Version,
+			"description": migration.Description,
+			"updated":     updated,
+		})
+
+		err = writeIndexMigrationVersion(ctx, backendClient, migration.Version)
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
+
+func applyIndexMigration(ctx context.Context, backendClient *opensearch.Client, migration *indexMigration) (int64, error) {
+
+	updateByQueryBody := map[string]interface{}{
+		"query": migration.Query,
+		"script": map[string]interface{}{
+			"source": migration.Script,
+			"lang":   "painless",
+			"params": map[string]interface{}{
+				"now": time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	bodyContent, err := json.Marshal(updateByQueryBody)
+	if err != nil {
+		return 0, err
+	}
+
+	// The character index does not exist until the first character is stored
+	allowNoIndices, ignoreUnavailable, refresh, waitForCompletion := true, true, true, true
+	updateByQueryRequest := opensearchapi.UpdateByQueryRequest{
+		Index:             []string{backendIndex},
+		Body:              bytes.NewReader(bodyContent),
+		Conflicts:         "proceed",
+		AllowNoIndices:    &allowNoIndices,
+		IgnoreUnavailable: &ignoreUnavailable,
+		Refresh:           &refresh,
+		WaitForCompletion: &waitForCompletion,
+	}
+
+	updateByQueryResponse, err := updateByQueryRequest.Do(ctx, backendClient)
+	if err != nil {
+		return 0, err
+	}
+	defer updateByQueryResponse.Body.Close()
+
+	backendResponse := &BackendUpdateByQueryResponse{}
+	err = decodeBackendResponse(updateByQueryResponse.StatusCode, updateByQueryResponse.Body, backendResponse)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(backendResponse.Failures) > 0 {
+		return backendResponse.Updated, fmt.Errorf("%d documents could not be updated, the first failure was: %s",
+			len(backendResponse.Failures), backendResponse.Failures[0])
+	}
+
+	return backendResponse.Updated, nil
+
+}
+
+func writeIndexMigrationVersion(ctx context.Context, backendClient *opensearch.Client, version int64) error {
+
+	bodyContent, err := json.Marshal(&IndexMigrationDocument{
+		Version:   version,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	indexRequest := opensearchapi.IndexRequest{
+		Index:      metaIndex,
+		DocumentID: indexMigrationDocumentID,
+		Body:       bytes.NewReader(bodyContent),
+		Refresh:    refreshTrue,
+	}
+
+	indexResponse, err := indexRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+	defer indexResponse.Body.Close()
+
+	return decodeBackendResponse(indexResponse.StatusCode, indexResponse.Body, nil)
+
+}
@@ -2,21 +2,37 @@ package buildonaws
 
 import (
 	"context"
+	"net/http"
 	"net/url"
+	"os"
+	"regexp"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/opensearch-project/opensearch-go/v2"
-	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 )
 
 var (
-	_           provider.Provider = &buildOnAWSProvider{}
-	commit, tag string            // populated by goreleaser
+	_           provider.Provider                       = &buildOnAWSProvider{}
+	_           provider.ProviderWithFunctions          = &buildOnAWSProvider{}
+	_           provider.ProviderWithEphemeralResources = &buildOnAWSProvider{}
+	_           provider.ProviderWithListResources      = &buildOnAWSProvider{}
+	_           provider.ProviderWithActions            = &buildOnAWSProvider{}
+	commit, tag string                                  // populated by goreleaser
 )
 
 func New() provider.Provider {
@@ -45,8 +61,10 @@ func New() provider.Provider {
 }
 
 type buildOnAWSProvider struct {
-	version string
-	commit  string
+	version    string
+	commit     string
+	traceMutex sync.Mutex
+	traceFile  *os.File
 }
 
 func (p *buildOnAWSProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -62,6 +80,125 @@ func (p *buildOnAWSProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Description: backendAddressFieldDesc,
 				Optional:    true,
 			},
+			backendTypeField: schema.StringAttribute{
+				Description: backendTypeFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(backendTypes...),
+				},
+			},
+			connectModeField: schema.StringAttribute{
+				Description: connectModeFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(connectModes...),
+				},
+			},
+			filePathField: schema.StringAttribute{
+				Description: filePathFieldDesc,
+				Optional:    true,
+			},
+			refreshField: schema.StringAttribute{
+				Description: refreshFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(refreshPolicies...),
+				},
+			},
+			maxRetriesField: schema.Int64Attribute{
+				Description: maxRetriesFieldDesc,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			retryMaxWaitField: schema.StringAttribute{
+				Description: retryMaxWaitFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			maxRequestsPerSecondField: schema.Float64Attribute{
+				Description: maxRequestsPerSecondFieldDesc,
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			maxConcurrentRequestsField: schema.Int64Attribute{
+				Description: maxConcurrentRequestsFieldDesc,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			proxyURLField: schema.StringAttribute{
+				Description: proxyURLFieldDesc,
+				Optional:    true,
+			},
+			noProxyField: schema.StringAttribute{
+				Description: noProxyFieldDesc,
+				Optional:    true,
+			},
+			headersField: schema.MapAttribute{
+				Description: headersFieldDesc,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			compressRequestsField: schema.BoolAttribute{
+				Description: compressRequestsFieldDesc,
+				Optional:    true,
+			},
+			maxIdleConnectionsField: schema.Int64Attribute{
+				Description: maxIdleConnectionsFieldDesc,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			maxConnectionsPerHostField: schema.Int64Attribute{
+				Description: maxConnectionsPerHostFieldDesc,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			traceFileField: schema.StringAttribute{
+				Description: traceFileFieldDesc,
+				Optional:    true,
+			},
+			identityEncryptionKeyField: schema.StringAttribute{
+				Description: identityEncryptionKeyFieldDesc,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(16),
+				},
+			},
+			fullNamePatternField: schema.StringAttribute{
+				Description: fullNamePatternFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			maxTextLengthField: schema.Int64Attribute{
+				Description: maxTextLengthFieldDesc,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			uniqueFullNamesField: schema.BoolAttribute{
+				Description: uniqueFullNamesFieldDesc,
+				Optional:    true,
+			},
+			apiKeyField: schema.StringAttribute{
+				Description: apiKeyProviderFieldDesc,
+				Optional:    true,
+				Sensitive:   true,
+			},
 		},
 	}
 }
@@ -73,6 +210,20 @@ func (p *buildOnAWSProvider) Configure(ctx context.Context, req provider.Configu
 	var config BuildOnAWSProviderModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendTypeValue := backendTypeOpenSearch
+	if !config.BackendType.IsNull() {
+		backendTypeValue = config.BackendType.ValueString()
+	}
+
+	// There is nothing to connect with when characters are stored locally
+	if backendTypeValue == backendTypeFile {
+		p.configureFileBackend(ctx, config, resp)
+		return
+	}
 
 	backendAddressValue := backendAddressDefault
 
@@ -95,44 +246,277 @@ func (p *buildOnAWSProvider) Configure(ctx context.Context, req provider.Configu
 
 	}
 
-	backendClient, _ := opensearch.NewClient(
-		opensearch.Config{
-			Addresses: []string{backendAddressValue},
-		},
-	)
+	connectModeValue := connectModeEager
+	if !config.ConnectMode.IsNull() {
+		connectModeValue = config.ConnectMode.ValueString()
+	}
+
+	maxRetriesValue := maxRetriesDefault
+	if !config.MaxRetries.IsNull() {
+		maxRetriesValue = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryMaxWaitValue := retryMaxWaitDefault
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWaitValue, _ = time.ParseDuration(config.RetryMaxWait.ValueString())
+	}
+
+	proxyURLValue := config.ProxyURL.ValueString()
+	if !config.ProxyURL.IsNull() {
+		_, err := url.ParseRequestURI(proxyURLValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(proxyURLField),
+				"Invalid URL for the proxy",
+				"Cannot connect with the proxy using the URL: '"+proxyURLValue+"'.",
+			)
+			return
+		}
+	}
 
-	pingRequest := opensearchapi.PingRequest{
-		Pretty:     true,
-		Human:      true,
-		ErrorTrace: true,
+	header := http.Header{}
+	customHeaders := make([]string, 0, len(config.Headers))
+	for name, value := range config.Headers {
+		header.Set(name, value.ValueString())
+		customHeaders = append(customHeaders, name)
+	}
+	if !config.APIKey.IsNull() && config.APIKey.ValueString() != "" {
+		header.Set("Authorization", "ApiKey "+config.APIKey.ValueString())
+	}
+
+	var baseTransport http.RoundTripper = &headerTransport{
+		transport: newBaseTransport(proxyURLValue, config.NoProxy.ValueString(),
+			int(config.MaxIdleConnections.ValueInt64()), int(config.MaxConnectionsPerHost.ValueInt64())),
+		header:   header,
+		compress: config.CompressRequests.ValueBool(),
 	}
-	r, err := pingRequest.Do(ctx, backendClient)
 
+	// Traces are taken before compression, so request bodies are readable
+	traceFile, err := p.openTraceFile(config.TraceFile.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failure connecting with the backend",
+		resp.Diagnostics.AddAttributeError(
+			path.Root(traceFileField),
+			"Failure opening the trace file",
 			"Reason: "+err.Error(),
 		)
+		return
+	}
+	if traceFile != nil {
+		tflog.Debug(ctx, "Tracing the requests sent to the backend", map[string]interface{}{
+			traceFileField: config.TraceFile.ValueString(),
+		})
+		baseTransport = newTraceTransport(baseTransport, traceFile, customHeaders)
+	}
+
+	// Every attempt made by the retries goes through the limits
+	limitedTransport := newLimitTransport(baseTransport,
+		config.MaxRequestsPerSecond.ValueFloat64(), int(config.MaxConcurrentRequests.ValueInt64()))
+
+	var transport http.RoundTripper = &retryTransport{
+		transport:  limitedTransport,
+		maxRetries: maxRetriesValue,
+		baseWait:   retryBaseWait,
+		maxWait:    retryMaxWaitValue,
+	}
+
+	// Backend types are named after the distribution they connect to
+	if connectModeValue == connectModeLazy {
+		transport = &connectionCheckTransport{
+			transport:    transport,
+			distribution: backendTypeValue,
+		}
+	}
+
+	var clusterName string
+	if connectModeValue == connectModeEager {
+
+		bodyContent, err := requestBackendInfo(ctx, transport, backendAddressValue, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failure connecting with the backend",
+				"Reason: "+err.Error(),
+			)
+			return
+		}
+
+		backendInfo, err := checkBackendCompatibility(bodyContent, backendTypeValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(backendAddressField),
+				"Unsupported backend",
+				"The backend at '"+backendAddressValue+"' cannot be used. Reason: "+err.Error()+".",
+			)
+			return
+		}
+
+		ctx = tflog.SetField(ctx, "backend_cluster_name", backendInfo.ClusterName)
+		ctx = tflog.SetField(ctx, "backend_distribution", backendInfo.Distribution)
+		ctx = tflog.SetField(ctx, "backend_version", backendInfo.Version.String())
+		tflog.Debug(ctx, "Response from the cluster info request")
+		clusterName = backendInfo.ClusterName
+
 	} else {
-		ctx = tflog.SetField(ctx, "ping_request_status", r.StatusCode)
-		ctx = tflog.SetField(ctx, "ping_request_header", r.Header)
-		ctx = tflog.SetField(ctx, "ping_request_body", r.Body)
-		tflog.Debug(ctx, "Response from the ping request")
+		tflog.Debug(ctx, "Connectivity with the backend not verified during configuration", map[string]interface{}{
+			connectModeField: connectModeValue,
+		})
+	}
+
+	refreshValue := refreshWaitFor
+	if !config.Refresh.IsNull() {
+		refreshValue = config.Refresh.ValueString()
+	}
+
+	backend, err := newBackend(backendTypeValue, backendAddressValue, refreshValue, transport)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failure creating the backend client",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	backend.cluster = clusterName
+	backend.connectMode = connectModeValue
+
+	p.encryptIdentities(config, backend, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p.applyCharacterRules(config, backend)
+
+	resp.DataSourceData = backend
+	resp.ResourceData = backend
+	resp.EphemeralResourceData = backend
+	resp.ListResourceData = backend
+	resp.ActionData = backend
+
+}
+
+func (p *buildOnAWSProvider) configureFileBackend(ctx context.Context, config BuildOnAWSProviderModel, resp *provider.ConfigureResponse) {
+
+	if config.FilePath.IsNull() || config.FilePath.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(filePathField),
+			"Missing file path",
+			"The file path must be set when the backend type is '"+backendTypeFile+"'.",
+		)
+		return
+	}
+
+	filePathValue := config.FilePath.ValueString()
+	tflog.Debug(ctx, "Storing characters in the local file system", map[string]interface{}{
+		filePathField: filePathValue,
+	})
+
+	// Writes are visible right away, so there is no refresh policy to apply
+	backend, err := newBackend(backendTypeFile, filePathValue, "", nil)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(filePathField),
+			"Failure creating the file backend",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	p.encryptIdentities(config, backend, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p.applyCharacterRules(config, backend)
+
+	resp.DataSourceData = backend
+	resp.ResourceData = backend
+	resp.EphemeralResourceData = backend
+	resp.ListResourceData = backend
+	resp.ActionData = backend
+
+}
+
+// encryptIdentities makes the backend encrypt the identity of characters,
+// when the provider is configured with a key to do so.
+func (p *buildOnAWSProvider) encryptIdentities(config BuildOnAWSProviderModel, backend *backend, resp *provider.ConfigureResponse) {
+
+	if config.IdentityEncryptionKey.IsNull() || config.IdentityEncryptionKey.ValueString() == "" {
+		return
+	}
+
+	store, err := newEncryptedCharacterStore(backend.characterStore, config.IdentityEncryptionKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(identityEncryptionKeyField),
+			"Failure setting up the identity encryption",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	backend.characterStore = store
+
+}
+
+// applyCharacterRules hands the validation rules set in the provider
+// configuration over to the character resource, which applies them while
+// planning. The pattern has already been checked by the schema validators.
+func (p *buildOnAWSProvider) applyCharacterRules(config BuildOnAWSProviderModel, backend *backend) {
+
+	if !config.FullNamePattern.IsNull() && config.FullNamePattern.ValueString() != "" {
+		backend.fullNamePattern = regexp.MustCompile(config.FullNamePattern.ValueString())
 	}
 
-	resp.DataSourceData = backendClient
-	resp.ResourceData = backendClient
+	backend.maxTextLength = characterTextMaxLength
+	if !config.MaxTextLength.IsNull() {
+		backend.maxTextLength = int(config.MaxTextLength.ValueInt64())
+	}
 
+	backend.uniqueFullNames = config.UniqueFullNames.ValueBool()
+
+}
+
+func (p *buildOnAWSProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewReindexAction,
+		NewRefreshIndexAction,
+		NewSnapshotAction,
+	}
 }
 
 func (p *buildOnAWSProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCharacterDataSource,
+		NewCharacterAppearancesDataSource,
+		NewCharacterStatsDataSource,
+		NewSearchDataSource,
+		NewClusterDataSource,
+	}
+}
+
+func (p *buildOnAWSProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAPIKeyEphemeralResource,
+	}
+}
+
+func (p *buildOnAWSProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCharacterSlugFunction,
+		NewIsValidTypeFunction,
+		NewParseImportIDFunction,
+	}
+}
+
+func (p *buildOnAWSProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewCharacterListResource,
 	}
 }
 
 func (p *buildOnAWSProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewCharacterResource,
+		NewAppearanceResource,
+		NewCharacterTypeResource,
+		NewIndexMigrationResource,
 	}
 }
@@ -1,11 +1,18 @@
 package buildonaws
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -15,6 +22,95 @@ var (
 	}
 )
 
+// newTestProviderServer configures the provider through the protocol,
+// the way Terraform does, to test what the acceptance test helpers cannot
+// reach, such as ephemeral resources. Attributes left out are null.
+func newTestProviderServer(t *testing.T, providerConfig map[string]tftypes.Value) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+
+	ctx := context.Background()
+	server, err := testAccProtoV6ProviderFactories[providerTypeName]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResponse, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configureResponse, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemaResponse.Provider, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range configureResponse.Diagnostics {
+		t.Fatalf("unexpected diagnostic while configuring the provider: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	return server, schemaResponse
+
+}
+
+func testDynamicValue(t *testing.T, schema *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+
+	objectType := schema.ValueType().(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		value, found := values[name]
+		if !found {
+			value = tftypes.NewValue(attributeType, nil)
+		}
+		attributes[name] = value
+	}
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, attributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &dynamicValue
+
+}
+
+// invokeTestAction invokes the action the way Terraform does, returning
+// the progress messages sent along with the diagnostics it completed with.
+func invokeTestAction(t *testing.T, server tfprotov6.ProviderServer, schemaResponse *tfprotov6.GetProviderSchemaResponse,
+	typeName string, values map[string]tftypes.Value) ([]string, []*tfprotov6.Diagnostic) {
+
+	actionServer, ok := server.(tfprotov6.ProviderServerWithActions)
+	if !ok {
+		t.Fatal("expected the provider server to serve actions")
+	}
+
+	actionSchema := schemaResponse.ActionSchemas[typeName]
+	if actionSchema == nil {
+		t.Fatalf("expected the %s action to be registered", typeName)
+	}
+
+	invokeResponse, err := actionServer.InvokeAction(context.Background(), &tfprotov6.InvokeActionRequest{
+		ActionType: typeName,
+		Config:     testDynamicValue(t, actionSchema.Schema, values),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	progress := []string{}
+	var diagnostics []*tfprotov6.Diagnostic
+	for event := range invokeResponse.Events {
+		switch eventType := event.Type.(type) {
+		case tfprotov6.ProgressInvokeActionEventType:
+			progress = append(progress, eventType.Message)
+		case tfprotov6.CompletedInvokeActionEventType:
+			diagnostics = eventType.Diagnostics
+		}
+	}
+
+	return progress, diagnostics
+
+}
+
 func TestAccProviderAddressValidation(t *testing.T) {
 
 	terrformConfig := `
@@ -60,3 +156,190 @@ func TestAccProviderConnectivity(t *testing.T) {
 	})
 
 }
+
+func TestAccProviderLazyConnectivity(t *testing.T) {
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "http://some-unknown-host:9200"
+		connect_mode = "lazy"
+	}
+
+	data "buildonaws_character" "deadpool" {
+		identity = "Wade Wilson"
+	}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      terrformConfig,
+				ExpectError: regexp.MustCompile("failure connecting with the backend"),
+			},
+		},
+	})
+
+}
+
+func TestAccProviderUnsupportedBackend(t *testing.T) {
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(openSearch1InfoResponse))
+	}))
+	defer backend.Close()
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	data "buildonaws_character" "deadpool" {
+		identity = "Wade Wilson"
+	}`
+
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${backend_address}", backend.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      terrformConfig,
+				ExpectError: regexp.MustCompile("Unsupported backend"),
+			},
+		},
+	})
+
+}
+
+func TestAccProviderFileBackend(t *testing.T) {
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_type = "file"
+		file_path = "${file_path}"
+	}
+
+	resource "buildonaws_character" "deadpool" {
+		fullname = "Deadpool"
+		identity = "Wade Wilson"
+		knownas = "Merc with a Mouth"
+		type = "anti-hero"
+	}
+
+	data "buildonaws_character" "deadpool" {
+		identity = buildonaws_character.deadpool.identity
+	}`
+
+	filePath := filepath.ToSlash(filepath.Join(t.TempDir(), "characters"))
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${file_path}", filePath)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: terrformConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.buildonaws_character.deadpool", "id", "buildonaws_character.deadpool", "id"),
+					resource.TestCheckResourceAttr("data.buildonaws_character.deadpool", "fullname", "Deadpool"),
+				),
+			},
+		},
+	})
+
+}
+
+func TestAccProviderFileBackendWithoutPath(t *testing.T) {
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_type = "file"
+	}
+
+	data "buildonaws_character" "deadpool" {
+		identity = "Wade Wilson"
+	}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      terrformConfig,
+				ExpectError: regexp.MustCompile("Missing file path"),
+			},
+		},
+	})
+
+}
+
+// applyTestResourceChange applies a change to the resource the way Terraform
+// does, planning the deletion of the resource when no values are planned.
+func applyTestResourceChange(t *testing.T, server tfprotov6.ProviderServer, schemaResponse *tfprotov6.GetProviderSchemaResponse,
+	typeName string, prior map[string]tftypes.Value, planned map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+
+	resourceSchema := schemaResponse.ResourceSchemas[typeName]
+	if resourceSchema == nil {
+		t.Fatalf("expected the %s resource to be registered", typeName)
+	}
+
+	nullValue := func() *tfprotov6.DynamicValue {
+		dynamicValue, err := tfprotov6.NewDynamicValue(resourceSchema.ValueType(), tftypes.NewValue(resourceSchema.ValueType(), nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dynamicValue
+	}
+
+	priorState, plannedState, config := nullValue(), nullValue(), nullValue()
+	if prior != nil {
+		priorState = testDynamicValue(t, resourceSchema, prior)
+	}
+	if planned != nil {
+		plannedState = testDynamicValue(t, resourceSchema, planned)
+		config = plannedState
+	}
+
+	applyResponse, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   priorState,
+		PlannedState: plannedState,
+		Config:       config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return applyResponse.Diagnostics
+
+}
+
+// readTestResource refreshes the resource the way Terraform does before planning.
+func readTestResource(t *testing.T, server tfprotov6.ProviderServer, schemaResponse *tfprotov6.GetProviderSchemaResponse,
+	typeName string, state map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+
+	resourceSchema := schemaResponse.ResourceSchemas[typeName]
+	if resourceSchema == nil {
+		t.Fatalf("expected the %s resource to be registered", typeName)
+	}
+
+	readResponse, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: testDynamicValue(t, resourceSchema, state),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return readResponse.Diagnostics
+
+}
+
+// newFailingBackend answers every request with the given error status,
+// like a backend denying access to the provider does.
+func newFailingBackend(statusCode int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		fmt.Fprintf(w, `{"error":{"type":"security_exception","reason":"action unauthorized"},"status":%d}`, statusCode)
+	}))
+}
@@ -0,0 +1,120 @@
+package buildonaws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ action.Action              = &refreshIndexAction{}
+	_ action.ActionWithConfigure = &refreshIndexAction{}
+)
+
+func NewRefreshIndexAction() action.Action {
+	return &refreshIndexAction{}
+}
+
+// refreshIndexAction makes recent writes searchable on demand, for
+// characters written with the refresh policy set to 'false'.
+type refreshIndexAction struct {
+	backendClient *opensearch.Client
+}
+
+func (r *refreshIndexAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + refreshIndexActionTypeName
+}
+
+func (r *refreshIndexAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: refreshIndexActionDesc,
+		Attributes: map[string]schema.Attribute{
+			indexField: schema.StringAttribute{
+				Description: refreshIndexFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *refreshIndexAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS refresh index action")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.backendClient = openSearchClientOf(req.ProviderData, refreshIndexActionTypeName, &resp.Diagnostics)
+
+}
+
+func (r *refreshIndexAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+
+	var refreshIndexConfig RefreshIndexActionModel
+	diags := req.Config.Get(ctx, &refreshIndexConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	index := backendIndex
+	if !refreshIndexConfig.Index.IsNull() {
+		index = refreshIndexConfig.Index.ValueString()
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Refreshing the index '" + index + "'",
+	})
+
+	backendResponse, err := refreshIndex(ctx, r.backendClient, index)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while refreshing index",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Refreshed %d of %d shards of the index '%s'",
+			backendResponse.Shards.Successful, backendResponse.Shards.Total, index),
+	})
+
+}
+
+func refreshIndex(ctx context.Context, backendClient *opensearch.Client, index string) (*BackendRefreshResponse, error) {
+
+	refreshRequest := opensearchapi.IndicesRefreshRequest{
+		Index: []string{index},
+	}
+
+	refreshResponse, err := refreshRequest.Do(ctx, backendClient)
+	if err != nil {
+		return nil, err
+	}
+	defer refreshResponse.Body.Close()
+
+	backendResponse := &BackendRefreshResponse{}
+	err = decodeBackendResponse(refreshResponse.StatusCode, refreshResponse.Body, backendResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replicas that are not allocated are not counted as failed
+	if backendResponse.Shards.Failed > 0 {
+		return nil, fmt.Errorf("the refresh failed on %d of %d shards", backendResponse.Shards.Failed, backendResponse.Shards.Total)
+	}
+
+	return backendResponse, nil
+
+}
@@ -0,0 +1,57 @@
+package buildonaws
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRefreshIndexAction(t *testing.T) {
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + refreshIndexActionTypeName
+
+	progress, diagnostics := invokeTestAction(t, server, schemaResponse, typeName, nil)
+	for _, diagnostic := range diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+	if len(progress) != 2 || !strings.Contains(progress[1], "Refreshed 1 of 2 shards") {
+		t.Errorf("expected the refreshed shards to be reported, got %v", progress)
+	}
+
+	_, diagnostics = invokeTestAction(t, server, schemaResponse, typeName, map[string]tftypes.Value{
+		indexField: tftypes.NewValue(tftypes.String, appearanceIndex),
+	})
+	for _, diagnostic := range diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	if len(stub.refreshedIndexes) != 2 || stub.refreshedIndexes[0] != backendIndex || stub.refreshedIndexes[1] != appearanceIndex {
+		t.Errorf("expected the character index and then the appearance index to be refreshed, got %v", stub.refreshedIndexes)
+	}
+
+}
+
+func TestRefreshIndexActionRequiresOpenSearch(t *testing.T) {
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendTypeField: tftypes.NewValue(tftypes.String, backendTypeFile),
+		filePathField:    tftypes.NewValue(tftypes.String, t.TempDir()),
+	})
+
+	_, diagnostics := invokeTestAction(t, server, schemaResponse, providerTypeName+refreshIndexActionTypeName, nil)
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Unsupported backend type" {
+		t.Errorf("expected the file backend to be rejected, got %+v", diagnostics)
+	}
+
+}
@@ -0,0 +1,337 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ action.Action              = &reindexAction{}
+	_ action.ActionWithConfigure = &reindexAction{}
+)
+
+func NewReindexAction() action.Action {
+	return &reindexAction{}
+}
+
+// reindexAction copies documents between indexes, which is how the
+// mappings of an index are changed once documents are stored in it.
+type reindexAction struct {
+	backendClient *opensearch.Client
+}
+
+func (r *reindexAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + reindexActionTypeName
+}
+
+func (r *reindexAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: reindexActionDesc,
+		Attributes: map[string]schema.Attribute{
+			sourceField: schema.StringAttribute{
+				Description: reindexSourceFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			destinationField: schema.StringAttribute{
+				Description: destinationFieldDesc,
+				Required:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			mappingsField: schema.StringAttribute{
+				Description: mappingsFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					jsonObjectValidator{},
+				},
+			},
+			aliasField: schema.StringAttribute{
+				Description: aliasFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *reindexAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS reindex action")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.backendClient = openSearchClientOf(req.ProviderData, reindexActionTypeName, &resp.Diagnostics)
+
+}
+
+func (r *reindexAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+
+	var reindexConfig ReindexActionModel
+	diags := req.Config.Get(ctx, &reindexConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := backendIndex
+	if !reindexConfig.Source.IsNull() {
+		source = reindexConfig.Source.ValueString()
+	}
+	destination := reindexConfig.Destination.ValueString()
+
+	if source == destination {
+		resp.Diagnostics.AddError(
+			"Invalid reindex destination",
+			"The documents of the index '"+source+"' cannot be copied into the same index.",
+		)
+		return
+	}
+
+	if !reindexConfig.Mappings.IsNull() {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: "Creating the index '" + destination + "' with the mappings given",
+		})
+		err := createIndex(ctx, r.backendClient, destination, reindexConfig.Mappings.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error while creating index",
+				"Reason: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Copying the documents of the index '" + source + "' into '" + destination + "'",
+	})
+
+	taskID, err := startReindex(ctx, r.backendClient, source, destination)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reindexing",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	status, err := waitForReindex(ctx, r.backendClient, taskID, func(status BackendReindexStatus) {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Copied %d of %d documents", status.Created+status.Updated, status.Total),
+		})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reindexing",
+			"The task '"+taskID+"' did not complete. Reason: "+err.Error(),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Copied %d documents into the index '%s': %d created and %d updated",
+			status.Created+status.Updated, destination, status.Created, status.Updated),
+	})
+
+	if reindexConfig.Alias.IsNull() {
+		return
+	}
+
+	alias := reindexConfig.Alias.ValueString()
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Moving the alias '" + alias + "' to the index '" + destination + "'",
+	})
+
+	err = moveAlias(ctx, r.backendClient, alias, destination)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while moving alias",
+			"The documents were copied into the index '"+destination+"', but the alias '"+alias+"' was not moved to it. Reason: "+err.Error(),
+		)
+		return
+	}
+
+}
+
+func createIndex(ctx context.Context, backendClient *opensearch.Client, index string, mappings string) error {
+
+	bodyContent, err := json.Marshal(map[string]json.RawMessage{
+		"mappings": json.RawMessage(mappings),
+	})
+	if err != nil {
+		return err
+	}
+
+	createRequest := opensearchapi.IndicesCreateRequest{
+		Index: index,
+		Body:  bytes.NewReader(bodyContent),
+	}
+
+	createResponse, err := createRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+	defer createResponse.Body.Close()
+
+	return decodeBackendResponse(createResponse.StatusCode, createResponse.Body, nil)
+
+}
+
+// moveAlias points the alias to the index alone, removing it from the
+// indexes holding it in the same request, as the backend applies the
+// actions of a single request atomically.
+func moveAlias(ctx context.Context, backendClient *opensearch.Client, alias string, index string) error {
+
+	getAliasRequest := opensearchapi.IndicesGetAliasRequest{
+		Name: []string{alias},
+	}
+
+	getAliasResponse, err := getAliasRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+	defer getAliasResponse.Body.Close()
+
+	// Keyed by the indexes holding the alias, none when it does not exist yet
+	aliasedIndexes := map[string]json.RawMessage{}
+	if getAliasResponse.StatusCode != http.StatusNotFound {
+		err = decodeBackendResponse(getAliasResponse.StatusCode, getAliasResponse.Body, &aliasedIndexes)
+		if err != nil {
+			return err
+		}
+	}
+
+	actions := []interface{}{}
+	for aliasedIndex := range aliasedIndexes {
+		if aliasedIndex != index {
+			actions = append(actions, map[string]interface{}{
+				"remove": map[string]string{"index": aliasedIndex, "alias": alias},
+			})
+		}
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]string{"index": index, "alias": alias},
+	})
+
+	bodyContent, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	updateAliasesRequest := opensearchapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(bodyContent),
+	}
+
+	updateAliasesResponse, err := updateAliasesRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+	defer updateAliasesResponse.Body.Close()
+
+	return decodeBackendResponse(updateAliasesResponse.StatusCode, updateAliasesResponse.Body, nil)
+
+}
+
+// startReindex runs the reindex as a task, rather than waiting for it in
+// the request, so the progress can be followed and reported.
+func startReindex(ctx context.Context, backendClient *opensearch.Client, source string, destination string) (string, error) {
+
+	bodyContent, err := json.Marshal(map[string]interface{}{
+		"source": map[string]string{"index": source},
+		"dest":   map[string]string{"index": destination},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	refresh, waitForCompletion := true, false
+	reindexRequest := opensearchapi.ReindexRequest{
+		Body:              bytes.NewReader(bodyContent),
+		Refresh:           &refresh,
+		WaitForCompletion: &waitForCompletion,
+	}
+
+	reindexResponse, err := reindexRequest.Do(ctx, backendClient)
+	if err != nil {
+		return "", err
+	}
+	defer reindexResponse.Body.Close()
+
+	backendResponse := &BackendReindexTaskResponse{}
+	err = decodeBackendResponse(reindexResponse.StatusCode, reindexResponse.Body, backendResponse)
+	if err != nil {
+		return "", err
+	}
+
+	if backendResponse.Task == "" {
+		return "", errors.New("the backend did not start a task for the reindex")
+	}
+
+	return backendResponse.Task, nil
+
+}
+
+// waitForReindex polls the task until it completes, reporting the status
+// of the reindex each time the task is still running.
+func waitForReindex(ctx context.Context, backendClient *opensearch.Client, taskID string, report func(BackendReindexStatus)) (*BackendReindexStatus, error) {
+
+	for {
+
+		taskRequest := opensearchapi.TasksGetRequest{
+			TaskID: taskID,
+		}
+
+		taskResponse, err := taskRequest.Do(ctx, backendClient)
+		if err != nil {
+			return nil, err
+		}
+
+		backendResponse := &BackendReindexTaskStatusResponse{}
+		err = decodeBackendResponse(taskResponse.StatusCode, taskResponse.Body, backendResponse)
+		taskResponse.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if backendResponse.Completed {
+			if backendResponse.Error != nil {
+				return nil, fmt.Errorf("%s %s", backendResponse.Error.Type, backendResponse.Error.Reason)
+			}
+			if backendResponse.Response == nil {
+				return nil, errors.New("the backend did not return the outcome of the task")
+			}
+			if len(backendResponse.Response.Failures) > 0 {
+				return nil, fmt.Errorf("%d documents could not be copied, the first failure being: %s",
+					len(backendResponse.Response.Failures), backendResponse.Response.Failures[0])
+			}
+			return &backendResponse.Response.BackendReindexStatus, nil
+		}
+
+		report(backendResponse.Task.Status)
+
+		err = sleepWithContext(ctx, reindexPollInterval)
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+}
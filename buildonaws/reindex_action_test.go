@@ -0,0 +1,144 @@
+package buildonaws
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestReindexAction(t *testing.T) {
+
+	stub := newBackendStub()
+	for i := 1; i <= 4; i++ {
+		stub.documents[strconv.Itoa(i)] = &ComicCharacter{FullName: "Character " + strconv.Itoa(i)}
+	}
+	stub.aliases["characters"] = map[string]bool{backendIndex: true}
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	defaultPollInterval := reindexPollInterval
+	reindexPollInterval = time.Millisecond
+	defer func() { reindexPollInterval = defaultPollInterval }()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + reindexActionTypeName
+	mappings := `{"properties":{"fullname":{"type":"keyword"}}}`
+
+	progress, diagnostics := invokeTestAction(t, server, schemaResponse, typeName, map[string]tftypes.Value{
+		destinationField: tftypes.NewValue(tftypes.String, "buildonaws_v2"),
+		mappingsField:    tftypes.NewValue(tftypes.String, mappings),
+		aliasField:       tftypes.NewValue(tftypes.String, "characters"),
+	})
+	for _, diagnostic := range diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	if stub.indexes["buildonaws_v2"] != mappings {
+		t.Errorf("expected the destination index to be created with the mappings, got '%s'", stub.indexes["buildonaws_v2"])
+	}
+
+	// The task is polled twice while running, then once it has completed
+	expectedProgress := []string{
+		"Creating the index 'buildonaws_v2' with the mappings given",
+		"Copying the documents of the index 'buildonaws' into 'buildonaws_v2'",
+		"Copied 1 of 4 documents",
+		"Copied 2 of 4 documents",
+		"Copied 4 documents into the index 'buildonaws_v2': 4 created and 0 updated",
+		"Moving the alias 'characters' to the index 'buildonaws_v2'",
+	}
+	if strings.Join(progress, "\n") != strings.Join(expectedProgress, "\n") {
+		t.Errorf("expected the progress %q, got %q", expectedProgress, progress)
+	}
+
+	if len(stub.aliases["characters"]) != 1 || !stub.aliases["characters"]["buildonaws_v2"] {
+		t.Errorf("expected the alias to point to the destination index alone, got %v", stub.aliases["characters"])
+	}
+
+}
+
+func TestReindexActionErrors(t *testing.T) {
+
+	stub := newBackendStub()
+	stub.indexes["buildonaws_v2"] = ""
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	testCases := map[string]struct {
+		values map[string]tftypes.Value
+		err    string
+	}{
+		"same index": {
+			values: map[string]tftypes.Value{
+				sourceField:      tftypes.NewValue(tftypes.String, backendIndex),
+				destinationField: tftypes.NewValue(tftypes.String, backendIndex),
+			},
+			err: "cannot be copied into the same index",
+		},
+		"existing index": {
+			values: map[string]tftypes.Value{
+				destinationField: tftypes.NewValue(tftypes.String, "buildonaws_v2"),
+				mappingsField:    tftypes.NewValue(tftypes.String, `{"properties":{}}`),
+			},
+			err: "resource_already_exists_exception",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			_, diagnostics := invokeTestAction(t, server, schemaResponse, providerTypeName+reindexActionTypeName, testCase.values)
+			if len(diagnostics) == 0 || !strings.Contains(diagnostics[0].Detail, testCase.err) {
+				t.Errorf("expected an error containing '%s', got %+v", testCase.err, diagnostics)
+			}
+
+		})
+	}
+
+	if len(stub.tasks) != 0 {
+		t.Errorf("expected no reindex to be started, got %v", stub.tasks)
+	}
+
+}
+
+func TestReindexActionAliasConflict(t *testing.T) {
+
+	stub := newBackendStub()
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	defaultPollInterval := reindexPollInterval
+	reindexPollInterval = time.Millisecond
+	defer func() { reindexPollInterval = defaultPollInterval }()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	// The source index still exists, so its name cannot be used as the alias
+	_, diagnostics := invokeTestAction(t, server, schemaResponse, providerTypeName+reindexActionTypeName, map[string]tftypes.Value{
+		destinationField: tftypes.NewValue(tftypes.String, "buildonaws_v2"),
+		aliasField:       tftypes.NewValue(tftypes.String, backendIndex),
+	})
+	if len(diagnostics) == 0 || diagnostics[0].Summary != "Error while moving alias" ||
+		!strings.Contains(diagnostics[0].Detail, "invalid_alias_name_exception") {
+		t.Errorf("expected the alias to be rejected, got %+v", diagnostics)
+	}
+	if len(stub.aliases) != 0 {
+		t.Errorf("expected no alias to be created, got %v", stub.aliases)
+	}
+
+}
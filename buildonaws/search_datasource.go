@@ -0,0 +1,222 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ datasource.DataSource              = &searchDataSource{}
+	_ datasource.DataSourceWithConfigure = &searchDataSource{}
+)
+
+// searchHiddenFields hold the identity of characters, either in clear or
+// encrypted along with its blind index, so they are left out of the hits
+// rather than written to the state where anyone reading it would see them.
+var searchHiddenFields = []string{identityField, identityEncryptedField}
+
+func NewSearchDataSource() datasource.DataSource {
+	return &searchDataSource{}
+}
+
+type searchDataSource struct {
+	backendClient *opensearch.Client
+}
+
+func (s *searchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + searchDataSourceTypeName
+}
+
+func (s *searchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			idField: schema.StringAttribute{
+				Computed: true,
+			},
+			queryField: schema.StringAttribute{
+				Description: queryFieldDesc,
+				Required:    true,
+				Validators: []validator.String{
+					jsonObjectValidator{},
+				},
+			},
+			indexField: schema.StringAttribute{
+				Description: indexFieldDesc,
+				Optional:    true,
+			},
+			sizeField: schema.Int64Attribute{
+				Description: sizeFieldDesc,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			sortField: schema.ListAttribute{
+				Description: sortFieldDesc,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			totalField: schema.Int64Attribute{
+				Description: totalHitsFieldDesc,
+				Computed:    true,
+			},
+			hitsField: schema.ListNestedAttribute{
+				Description: hitsFieldDesc,
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						idField: schema.StringAttribute{
+							Description: hitIDFieldDesc,
+							Computed:    true,
+						},
+						indexField: schema.StringAttribute{
+							Description: hitIndexFieldDesc,
+							Computed:    true,
+						},
+						scoreField: schema.Float64Attribute{
+							Description: scoreFieldDesc,
+							Computed:    true,
+						},
+						sourceField: schema.MapAttribute{
+							Description: sourceFieldDesc,
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						sourceJSONField: schema.StringAttribute{
+							Description: sourceJSONFieldDesc,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			hitsJSONField: schema.StringAttribute{
+				Description: hitsJSONFieldDesc,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (s *searchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS search datasource")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	s.backendClient = openSearchClientOf(req.ProviderData, searchDataSourceTypeName, &resp.Diagnostics)
+
+}
+
+func (s *searchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var searchConfig SearchDataSourceModel
+	diags := req.Config.Get(ctx, &searchConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	searchIndex := backendIndex
+	if !searchConfig.Index.IsNull() {
+		searchIndex = searchConfig.Index.ValueString()
+	}
+
+	searchRequest := opensearchapi.SearchRequest{
+		Index: []string{searchIndex},
+		Body:  strings.NewReader(searchConfig.Query.ValueString()),
+	}
+	if !searchConfig.Size.IsNull() {
+		size := int(searchConfig.Size.ValueInt64())
+		searchRequest.Size = &size
+	}
+	for _, sort := range searchConfig.Sort {
+		searchRequest.Sort = append(searchRequest.Sort, sort.ValueString())
+	}
+
+	searchResponse, err := searchRequest.Do(ctx, s.backendClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while searching the backend",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+	defer searchResponse.Body.Close()
+
+	bodyContent, err := io.ReadAll(searchResponse.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading response",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	if searchResponse.IsError() {
+		errorResponse := &BackendErrorResponse{}
+		json.Unmarshal(bodyContent, errorResponse)
+		resp.Diagnostics.AddError(
+			"Error while searching the backend",
+			"Reason: "+searchResponse.Status()+" "+errorResponse.Error.Type+": "+errorResponse.Error.Reason,
+		)
+		return
+	}
+
+	rawSearchResponse := &BackendRawSearchResponse{}
+	err = json.Unmarshal(bodyContent, rawSearchResponse)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while unmarshalling response",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	searchConfig.ID = types.StringValue(searchIndex)
+	searchConfig.Total = types.Int64Value(rawSearchResponse.Hits.Total.Value)
+	searchConfig.Hits = []SearchHitModel{}
+	for _, hit := range rawSearchResponse.Hits.Hits {
+		for _, field := range searchHiddenFields {
+			delete(hit.Source, field)
+		}
+		source := make(map[string]types.String, len(hit.Source))
+		for field, rawValue := range hit.Source {
+			var stringValue string
+			if json.Unmarshal(rawValue, &stringValue) != nil {
+				stringValue = string(rawValue)
+			}
+			source[field] = types.StringValue(stringValue)
+		}
+		sourceJSON, _ := json.Marshal(hit.Source)
+		searchConfig.Hits = append(searchConfig.Hits, SearchHitModel{
+			ID:         types.StringValue(hit.ID),
+			Index:      types.StringValue(hit.Index),
+			Score:      types.Float64PointerValue(hit.Score),
+			Source:     source,
+			SourceJSON: types.StringValue(string(sourceJSON)),
+		})
+	}
+
+	hitsJSON, _ := json.Marshal(rawSearchResponse.Hits.Hits)
+	searchConfig.HitsJSON = types.StringValue(string(hitsJSON))
+
+	diags = resp.State.Set(ctx, &searchConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
@@ -0,0 +1,141 @@
+package buildonaws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSearchDataSource(t *testing.T) {
+
+	ctx := context.Background()
+
+	backendContainer, err := createBackendContainer(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	character := &ComicCharacter{
+		FullName: "Black Widow",
+		Identity: "Natasha Romanoff",
+		KnownAs:  "The red room graduate",
+		Type:     characterTypes[0],
+		Universe: "search-test",
+	}
+
+	err = createCharacter(ctx, character, backendContainer)
+	if err != nil {
+		t.Error(err)
+	}
+
+	terrformConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	data "buildonaws_search" "widow" {
+		query = jsonencode({
+			query = {
+				match = {
+					universe = "search-test"
+				}
+			}
+		})
+		size = 5
+		sort = ["fullname.keyword:asc"]
+	}`
+
+	invalidQueryConfig := `
+	provider "buildonaws" {
+		backend_address = "${backend_address}"
+	}
+
+	data "buildonaws_search" "widow" {
+		query = "{ this is not json"
+	}`
+
+	terrformConfig = strings.ReplaceAll(terrformConfig, "${backend_address}", backendContainer.Address)
+	invalidQueryConfig = strings.ReplaceAll(invalidQueryConfig, "${backend_address}", backendContainer.Address)
+
+	dataSourceName := "data.buildonaws_search.widow"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      invalidQueryConfig,
+				ExpectError: regexp.MustCompile("Invalid JSON object"),
+			},
+			{
+				Config: terrformConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify if the hits match with what is expected
+					resource.TestCheckResourceAttr(dataSourceName, totalField, "1"),
+					resource.TestCheckResourceAttr(dataSourceName, hitsField+".#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, hitsField+".0."+indexField, backendIndex),
+					resource.TestCheckResourceAttr(dataSourceName, hitsField+".0."+sourceField+"."+fullNameField, character.FullName),
+					resource.TestCheckResourceAttrSet(dataSourceName, hitsField+".0."+idField),
+					resource.TestMatchResourceAttr(dataSourceName, hitsJSONField, regexp.MustCompile(character.Identity)),
+				),
+			},
+		},
+	})
+
+}
+
+func TestSearchDataSourceHidesIdentity(t *testing.T) {
+
+	ctx := context.Background()
+
+	// A character stored in clear next to one stored encrypted
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hits": {"total": {"value": 2}, "hits": [
+			{"_id": "1", "_index": "buildonaws", "_source": {"fullname": "Daredevil", "identity": "Matt Murdock"}},
+			{"_id": "2", "_index": "buildonaws", "_source": {"fullname": "Elektra", "identity": "5f3a9c", "identity_encrypted": "c2VjcmV0"}}
+		]}}`))
+	}))
+	defer backend.Close()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + searchDataSourceTypeName
+	dataSourceSchema := schemaResponse.DataSourceSchemas[typeName]
+	readResponse, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config: testDynamicValue(t, dataSourceSchema, map[string]tftypes.Value{
+			queryField: tftypes.NewValue(tftypes.String, `{"query":{"match_all":{}}}`),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range readResponse.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	state, err := readResponse.State.Unmarshal(dataSourceSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateContent := state.String()
+	for _, hidden := range []string{"Murdock", "5f3a9c", "c2VjcmV0", identityEncryptedField} {
+		if strings.Contains(stateContent, hidden) {
+			t.Errorf("expected '%s' to be left out of the state, got %s", hidden, stateContent)
+		}
+	}
+	if !strings.Contains(stateContent, "Elektra") {
+		t.Errorf("expected the other fields to be kept, got %s", stateContent)
+	}
+
+}
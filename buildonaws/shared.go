@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/go-connections/nat"
 	"github.com/testcontainers/testcontainers-go"
@@ -15,12 +17,67 @@ import (
 )
 
 var (
-	providerTypeName        = "buildonaws"
-	backendIndex            = providerTypeName
-	providerDesc            = "Provider to manage characters from comic books."
-	backendAddressField     = "backend_address"
-	backendAddressFieldDesc = "Address to connect to the OpenSearch backend."
-	backendAddressDefault   = "http://localhost:9200"
+	providerTypeName               = "buildonaws"
+	backendIndex                   = providerTypeName
+	providerDesc                   = "Provider to manage characters from comic books."
+	backendAddressField            = "backend_address"
+	backendAddressFieldDesc        = "Address to connect to the backend."
+	backendAddressDefault          = "http://localhost:9200"
+	backendTypeField               = "backend_type"
+	backendTypeOpenSearch          = "opensearch"
+	backendTypeElastic             = "elasticsearch"
+	backendTypeFile                = "file"
+	backendTypes                   = []string{backendTypeOpenSearch, backendTypeElastic, backendTypeFile}
+	backendTypeFieldDesc           = "The type of backend that stores the characters. Possible values: '" + strings.Join(backendTypes, ",") + "'. Defaults to '" + backendTypeOpenSearch + "'."
+	connectModeField               = "connect_mode"
+	connectModeEager               = "eager"
+	connectModeLazy                = "lazy"
+	connectModeSkip                = "skip"
+	connectModes                   = []string{connectModeEager, connectModeLazy, connectModeSkip}
+	connectModeFieldDesc           = "When to verify the connectivity with the backend: while configuring the provider ('eager'), on the first request sent to the backend ('lazy'), or never ('skip'). When skipped, the cluster info is still read, without checking the distribution and version, to name the cluster where characters are stored. Defaults to '" + connectModeEager + "'."
+	filePathField                  = "file_path"
+	filePathFieldDesc              = "Path of the directory, or of the single '.json' file, where characters are stored when the backend type is '" + backendTypeFile + "'."
+	refreshField                   = "refresh"
+	refreshTrue                    = "true"
+	refreshWaitFor                 = "wait_for"
+	refreshFalse                   = "false"
+	refreshPolicies                = []string{refreshTrue, refreshWaitFor, refreshFalse}
+	refreshFieldDesc               = "When changes made by resources become visible to searches: right away by refreshing the index ('true'), once the next refresh happens ('wait_for'), or whenever the backend refreshes the index without waiting for it ('false'). Defaults to '" + refreshWaitFor + "'."
+	maxRetriesField                = "max_retries"
+	maxRetriesDefault              = 3
+	maxRetriesFieldDesc            = "How many times requests that failed because of transient errors, such as '429 Too Many Requests' or '503 Service Unavailable', are retried. Dropped connections and gateway errors are only retried for requests that can be sent twice safely, which excludes creating characters. Defaults to '" + strconv.Itoa(maxRetriesDefault) + "'."
+	retryMaxWaitField              = "retry_max_wait"
+	retryMaxWaitDefault            = 30 * time.Second
+	retryBaseWait                  = 200 * time.Millisecond
+	retryMaxWaitFieldDesc          = "The longest time to wait between retries, such as '10s' or '1m'. Defaults to '" + retryMaxWaitDefault.String() + "'."
+	maxRequestsPerSecondField      = "max_requests_per_second"
+	maxRequestsPerSecondFieldDesc  = "The maximum number of requests sent to the backend per second, shared by all resources and data sources. Defaults to '0', which means unlimited."
+	maxConcurrentRequestsField     = "max_concurrent_requests"
+	maxConcurrentRequestsFieldDesc = "The maximum number of requests sent to the backend at the same time, shared by all resources and data sources. Defaults to '0', which means unlimited."
+	proxyURLField                  = "proxy_url"
+	proxyURLFieldDesc              = "Address of the HTTP proxy used to reach the backend. Defaults to the proxy set by the 'HTTP_PROXY' and 'HTTPS_PROXY' environment variables."
+	noProxyField                   = "no_proxy"
+	noProxyFieldDesc               = "Comma-separated list of hosts that are reached without going through the proxy. Defaults to the value of the 'NO_PROXY' environment variable."
+	headersField                   = "headers"
+	headersFieldDesc               = "Headers added to every request sent to the backend, such as the ones used to select a tenant."
+	compressRequestsField          = "compress_requests"
+	compressRequestsFieldDesc      = "Whether the body of the requests sent to the backend is compressed using gzip. Defaults to 'false'."
+	maxIdleConnectionsField        = "max_idle_connections"
+	maxIdleConnectionsFieldDesc    = "The maximum number of idle connections kept open with the backend. Defaults to '100'."
+	maxConnectionsPerHostField     = "max_connections_per_host"
+	maxConnectionsPerHostFieldDesc = "The maximum number of connections opened with the backend, including the ones in use. Defaults to '0', which means unlimited."
+	traceFileField                 = "trace_file"
+	traceFileFieldDesc             = "Path of a file where every request sent to the backend, and the response to it, is appended as a line of NDJSON. Credentials, the values of custom headers and identities are redacted. Meant for attaching traces to bug reports."
+	identityEncryptionKeyField     = "identity_encryption_key"
+	identityEncryptionKeyFieldDesc = "Secret used to encrypt the identity of characters before storing them, with AES-GCM. Characters are then looked up by the exact identity they were stored with. Must be at least 16 characters long."
+	fullNamePatternField           = "fullname_pattern"
+	fullNamePatternFieldDesc       = "Regular expression that the fullname of every character managed by the provider must match, checked while planning."
+	maxTextLengthField             = "max_text_length"
+	maxTextLengthFieldDesc         = "The maximum number of characters in the fullname, identity and knownas of every character managed by the provider, checked while planning. Defaults to '" + strconv.Itoa(characterTextMaxLength) + "'."
+	uniqueFullNamesField           = "unique_fullnames"
+	uniqueFullNamesFieldDesc       = "Whether characters with the same fullname within the same universe are rejected while planning, by looking them up in the backend. Defaults to 'false'."
+	apiKeyProviderFieldDesc        = "Encoded API key used to authenticate with the backend, such as the one opened by the buildonaws_api_key ephemeral resource. Sent in the 'Authorization' header of every request."
+	aggregationBucketsSize         = 1000
 )
 
 var (
@@ -31,15 +88,222 @@ var (
 	fullNameField               = "fullname"
 	fullNameFieldDesc           = "The name to which we know the character of."
 	identityField               = "identity"
-	identityFieldDesc           = "The real name of the character, which is usually a secret."
+	identityEncryptedField      = "identity_encrypted"
+	identityFieldDesc           = "The real name of the character, which is usually a secret. It is stored encrypted when the provider is configured with an identity encryption key."
 	knownasField                = "knownas"
 	knowasFieldDesc             = "A catchphrase for which we know the character of."
 	typeField                   = "type"
 	characterTypes              = []string{"hero", "super-hero", "anti-hero", "villain"}
-	typeFieldDesc               = "The type of character. Possible values: '" + strings.Join(characterTypes, ",") + "'."
+	typeFieldDesc               = "The type of character. Built-in values: '" + strings.Join(characterTypes, ",") + "'. Additional types can be registered with the buildonaws_character_type resource."
+	createdAtField              = "created_at"
+	createdAtFieldDesc          = "When the character was created, in RFC3339 format and UTC, as stored in the backend."
 	lastUpdatedField            = "last_updated"
+	lastUpdatedFieldDesc        = "When the character was last updated, in RFC3339 format and UTC, as stored in the backend."
+	timeoutsField               = "timeouts"
+	createTimeoutDefault        = 5 * time.Minute
+	readTimeoutDefault          = 2 * time.Minute
+	updateTimeoutDefault        = 5 * time.Minute
+	deleteTimeoutDefault        = 5 * time.Minute
+	createTimeoutDesc           = "How long to wait for the character to be created, such as '30s' or '2m'. Defaults to '" + createTimeoutDefault.String() + "'."
+	readTimeoutDesc             = "How long to wait for the character to be read, such as '30s' or '2m'. Defaults to '" + readTimeoutDefault.String() + "'."
+	updateTimeoutDesc           = "How long to wait for the character to be updated, such as '30s' or '2m'. Defaults to '" + updateTimeoutDefault.String() + "'."
+	deleteTimeoutDesc           = "How long to wait for the character to be deleted, such as '30s' or '2m'. Defaults to '" + deleteTimeoutDefault.String() + "'."
+	universeField               = "universe"
+	universeFieldDesc           = "The fictional universe in which the character lives."
+	tagsField                   = "tags"
+	tagsFieldDesc               = "A set of tags used to categorize the character."
+	characterTextMaxLength      = 256
+	fullNameSearchSize          = 100
 )
 
+var (
+	characterStatsDataSourceTypeName = "_character_stats"
+	totalField                       = "total"
+	totalFieldDesc                   = "Total number of characters stored in the backend."
+	byTypeField                      = "by_type"
+	byTypeFieldDesc                  = "Number of characters of each type."
+	byTagField                       = "by_tag"
+	byTagFieldDesc                   = "Number of characters with each tag."
+	byUniverseField                  = "by_universe"
+	byUniverseFieldDesc              = "Number of characters that live in each universe."
+)
+
+var (
+	searchDataSourceTypeName = "_search"
+	queryField               = "query"
+	queryFieldDesc           = "JSON-encoded body of the search request, written using the OpenSearch query DSL."
+	indexField               = "index"
+	indexFieldDesc           = "The index to search. Defaults to the index where characters are stored."
+	sizeField                = "size"
+	sizeFieldDesc            = "Maximum number of hits to return."
+	sortField                = "sort"
+	sortFieldDesc            = "List of 'field:direction' pairs used to sort the hits."
+	hitsField                = "hits"
+	hitsFieldDesc            = "The hits returned by the search."
+	hitIndexFieldDesc        = "The index where the hit is stored."
+	hitIDFieldDesc           = "Unique identifier of the hit."
+	scoreField               = "score"
+	scoreFieldDesc           = "The relevance score of the hit."
+	sourceField              = "source"
+	sourceFieldDesc          = "The fields of the hit. Values that are not strings are JSON-encoded. The identity of characters is left out."
+	sourceJSONField          = "source_json"
+	sourceJSONFieldDesc      = "The JSON-encoded source of the hit. The identity of characters is left out."
+	hitsJSONField            = "hits_json"
+	hitsJSONFieldDesc        = "The JSON-encoded list of hits, as returned by the backend without the identity of characters."
+	totalHitsFieldDesc       = "Total number of documents that match the query."
+)
+
+var (
+	clusterDataSourceTypeName = "_cluster"
+	clusterNameField          = "cluster_name"
+	clusterNameFieldDesc      = "The name of the backend cluster."
+	distributionField         = "distribution"
+	distributionFieldDesc     = "The distribution of the backend, such as 'opensearch'."
+	versionField              = "version"
+	versionFieldDesc          = "The version of the backend."
+	healthStatusField         = "status"
+	healthStatusFieldDesc     = "The health status of the cluster. Possible values: 'green,yellow,red'."
+	numberOfNodesField        = "number_of_nodes"
+	numberOfNodesFieldDesc    = "The number of nodes in the cluster."
+	indexExistsField          = "index_exists"
+	indexExistsFieldDesc      = "Whether the index where characters are stored exists."
+)
+
+var (
+	characterTypeResourceTypeName = "_character_type"
+	characterTypeIndex            = backendIndex + "_types"
+	characterTypeIDFieldDesc      = "Unique identifier of the character type, which is the same as its name."
+	nameField                     = "name"
+	nameFieldDesc                 = "The name of the character type, used as the value of the type attribute of characters."
+	descriptionField              = "description"
+	descriptionFieldDesc          = "A description of what characters of this type are."
+)
+
+var (
+	appearanceResourceTypeName             = "_appearance"
+	characterAppearancesDataSourceTypeName = "_character_appearances"
+	appearanceIndex                        = backendIndex + "_appearances"
+	appearanceIDFieldDesc                  = "Unique identifier of the appearance."
+	characterIDField                       = "character_id"
+	characterIDFieldDesc                   = "Unique identifier of the character that appears in the issue."
+	characterIDFilterFieldDesc             = "When set, only the appearances of this character are aggregated."
+	seriesField                            = "series"
+	seriesFieldDesc                        = "The comic book series in which the issue was published."
+	issueNumberField                       = "issue_number"
+	issueNumberFieldDesc                   = "The number of the issue within the series."
+	publicationDateField                   = "publication_date"
+	publicationDateFieldDesc               = "The date in which the issue was published, using the format 'YYYY-MM-DD'."
+	roleField                              = "role"
+	appearanceRoles                        = []string{"lead", "supporting", "cameo"}
+	roleFieldDesc                          = "The role of the character in the issue. Possible values: '" + strings.Join(appearanceRoles, ",") + "'."
+	appearancesField                       = "appearances"
+	appearancesFieldDesc                   = "Number of appearances of each character, aggregated from the issues stored in the backend."
+	countField                             = "count"
+	countFieldDesc                         = "Total number of issues in which the character appears."
+	rolesField                             = "roles"
+	rolesFieldDesc                         = "Number of issues in which the character appears, broken down by role."
+)
+
+var (
+	indexMigrationResourceTypeName = "_index_migration"
+	metaIndex                      = backendIndex + "_meta"
+	indexMigrationDocumentID       = "migrations"
+	indexMigrationIDFieldDesc      = "Unique identifier of the meta document tracking the migrations applied to the character index."
+	targetVersionField             = "target_version"
+	targetVersionFieldDesc         = "The version up to which migrations are applied to the character index. Defaults to the latest version, so migrations shipped with new versions of the provider are applied on the next run. Migrations cannot be reverted, so a target before the version already applied only raises a warning."
+	latestVersionField             = "latest_version"
+	latestVersionFieldDesc         = "The version of the latest migration shipped with the provider."
+)
+
+var (
+	characterSlugFunctionName    = "character_slug"
+	characterSlugFunctionSummary = "Turn the fullname of a character into a slug"
+	characterSlugFunctionDesc    = "Lowercases the fullname and replaces every run of characters other than letters and digits with a single dash, such as 'spider-man' for 'Spider-Man'."
+	isValidTypeFunctionName      = "is_valid_type"
+	isValidTypeFunctionSummary   = "Check whether a character type is built-in"
+	isValidTypeFunctionDesc      = "Returns whether the type is one of the built-in character types ('" + strings.Join(characterTypes, ",") + "'). Types registered with the buildonaws_character_type resource live in the backend, so they are not known to the function."
+	parseImportIDFunctionName    = "parse_import_id"
+	parseImportIDFunctionSummary = "Split an identifier prefixed by the index of the document"
+	parseImportIDFunctionDesc    = "Splits an identifier of the form '[<index>/]<document_id>' into the index and the identifier of the document, which is the identifier resources are imported with. The index defaults to '" + backendIndex + "', where characters are stored."
+	fullNameParameterDesc        = "The fullname of the character."
+	typeParameterDesc            = "The type of character."
+	importIDParameterDesc        = "The identifier of a document, optionally prefixed by its index."
+	documentIDField              = "document_id"
+	importIDSeparator            = "/"
+	importIndexes                = []string{backendIndex, characterTypeIndex, appearanceIndex}
+)
+
+var (
+	apiKeyEphemeralResourceTypeName = "_api_key"
+	apiKeyEphemeralResourceDesc     = "Creates an API key that lasts as long as the Terraform operation using it, and revokes it once the operation is done. Only available with the '" + backendTypeElastic + "' backend type, as OpenSearch has no API keys."
+	apiKeyNameFieldDesc             = "The name of the API key, shown by the backend when listing keys."
+	expirationField                 = "expiration"
+	expirationDefault               = time.Hour
+	expirationFieldDesc             = "How long the API key stays valid if it is not revoked, such as '30m' or '2h'. Defaults to '" + expirationDefault.String() + "'."
+	apiKeyIDFieldDesc               = "Unique identifier of the API key."
+	apiKeyField                     = "api_key"
+	apiKeyFieldDesc                 = "The secret of the API key."
+	encodedField                    = "encoded"
+	encodedFieldDesc                = "The identifier and secret of the API key, encoded as expected by the 'api_key' provider attribute."
+	expiresAtField                  = "expires_at"
+	expiresAtFieldDesc              = "When the API key expires, in RFC3339 format and UTC."
+	apiKeyPrivateKey                = "api_key_id"
+)
+
+var (
+	clusterField                = "cluster"
+	identityClusterFieldDesc    = "The name of the cluster where the character is stored. Null when the backend type is '" + backendTypeFile + "', as files are not stored in a cluster."
+	identityIndexFieldDesc      = "The index where the character is stored."
+	identityDocumentIDFieldDesc = "Unique identifier of the document that stores the character."
+	fullNameFilterFieldDesc     = "When set, only the characters with this fullname are listed, ignoring case."
+	typeFilterFieldDesc         = "When set, only the characters of this type are listed."
+	universeFilterFieldDesc     = "When set, only the characters that live in this universe are listed, ignoring case."
+	listPageSize                = 100
+	listScrollKeepAlive         = time.Minute
+)
+
+var (
+	refreshIndexActionTypeName = "_refresh_index"
+	refreshIndexActionDesc     = "Refreshes an index, so the documents written to it since the last refresh become visible to searches."
+	refreshIndexFieldDesc      = "The index to refresh. Defaults to '" + backendIndex + "', where characters are stored."
+	reindexActionTypeName      = "_reindex"
+	reindexActionDesc          = "Copies the documents of an index into another one, such as a new index for the characters created with different mappings. Progress is reported while the backend copies the documents."
+	reindexSourceFieldDesc     = "The index to copy the documents from. Defaults to '" + backendIndex + "', where characters are stored."
+	destinationField           = "destination"
+	destinationFieldDesc       = "The index to copy the documents into. It must be different from the source index."
+	mappingsField              = "mappings"
+	mappingsFieldDesc          = "JSON-encoded mappings of the destination index, which is created with them before copying the documents. When not set, the documents are copied into the destination index as it is, which the backend creates with dynamic mappings if it does not exist."
+	aliasField                 = "alias"
+	aliasFieldDesc             = "Alias moved to the destination index once the documents are copied. It is removed from the indexes holding it and added to the destination in a single request, so searches through it see either index in full. An alias cannot have the name of an existing index, so the provider keeps writing to the index '" + backendIndex + "' unless that index is deleted and its name used as the alias."
+	reindexPollInterval        = 2 * time.Second
+	snapshotActionTypeName     = "_snapshot"
+	snapshotActionDesc         = "Takes a snapshot of an index into a snapshot repository registered in the backend, such as before reindexing the characters. Progress is reported while the backend copies the shards."
+	repositoryField            = "repository"
+	repositoryFieldDesc        = "The snapshot repository to store the snapshot in, which must be registered in the backend."
+	snapshotField              = "snapshot"
+	snapshotFieldDesc          = "The name of the snapshot, which must not be used yet in the repository."
+	snapshotIndexFieldDesc     = "The index to take a snapshot of. Defaults to '" + backendIndex + "', where characters are stored."
+	snapshotPollInterval       = 2 * time.Second
+)
+
+func isBuiltInCharacterType(characterType string) bool {
+	for _, builtInType := range characterTypes {
+		if characterType == builtInType {
+			return true
+		}
+	}
+	return false
+}
+
+// nonNilTags makes sure that characters stored without
+// tags are represented as an empty set instead of null.
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
 type backendContainer struct {
 	Container testcontainers.Container
 	Address   string
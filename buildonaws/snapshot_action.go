@@ -0,0 +1,203 @@
+package buildonaws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+var (
+	_ action.Action              = &snapshotAction{}
+	_ action.ActionWithConfigure = &snapshotAction{}
+)
+
+func NewSnapshotAction() action.Action {
+	return &snapshotAction{}
+}
+
+// snapshotAction backs up an index on demand, so it can be restored
+// should an operation such as a reindex go wrong.
+type snapshotAction struct {
+	backendClient *opensearch.Client
+}
+
+func (s *snapshotAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + snapshotActionTypeName
+}
+
+func (s *snapshotAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: snapshotActionDesc,
+		Attributes: map[string]schema.Attribute{
+			repositoryField: schema.StringAttribute{
+				Description: repositoryFieldDesc,
+				Required:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			snapshotField: schema.StringAttribute{
+				Description: snapshotFieldDesc,
+				Required:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+			indexField: schema.StringAttribute{
+				Description: snapshotIndexFieldDesc,
+				Optional:    true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (s *snapshotAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+
+	tflog.Info(ctx, "Configuring the BuildOnAWS snapshot action")
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	s.backendClient = openSearchClientOf(req.ProviderData, snapshotActionTypeName, &resp.Diagnostics)
+
+}
+
+func (s *snapshotAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+
+	var snapshotConfig SnapshotActionModel
+	diags := req.Config.Get(ctx, &snapshotConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	index := backendIndex
+	if !snapshotConfig.Index.IsNull() {
+		index = snapshotConfig.Index.ValueString()
+	}
+	repository := snapshotConfig.Repository.ValueString()
+	snapshot := snapshotConfig.Snapshot.ValueString()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Taking the snapshot '" + snapshot + "' of the index '" + index + "' into the repository '" + repository + "'",
+	})
+
+	err := startSnapshot(ctx, s.backendClient, repository, snapshot, index)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while taking snapshot",
+			"Reason: "+err.Error(),
+		)
+		return
+	}
+
+	status, err := waitForSnapshot(ctx, s.backendClient, repository, snapshot, func(status BackendSnapshotStatus) {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Copied %d of %d shards", status.ShardsStats.Done, status.ShardsStats.Total),
+		})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while taking snapshot",
+			"The snapshot '"+snapshot+"' did not complete. Reason: "+err.Error(),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Took the snapshot '%s' of %d shards", snapshot, status.ShardsStats.Total),
+	})
+
+}
+
+// startSnapshot leaves the snapshot running in the background, rather
+// than waiting for it in the request, so the progress can be reported.
+func startSnapshot(ctx context.Context, backendClient *opensearch.Client, repository string, snapshot string, index string) error {
+
+	// The cluster state is left out, as only the documents are worth restoring
+	bodyContent, err := json.Marshal(map[string]interface{}{
+		"indices":              index,
+		"include_global_state": false,
+	})
+	if err != nil {
+		return err
+	}
+
+	waitForCompletion := false
+	createRequest := opensearchapi.SnapshotCreateRequest{
+		Repository:        repository,
+		Snapshot:          snapshot,
+		Body:              bytes.NewReader(bodyContent),
+		WaitForCompletion: &waitForCompletion,
+	}
+
+	createResponse, err := createRequest.Do(ctx, backendClient)
+	if err != nil {
+		return err
+	}
+	defer createResponse.Body.Close()
+
+	return decodeBackendResponse(createResponse.StatusCode, createResponse.Body, nil)
+
+}
+
+// waitForSnapshot polls the status of the snapshot until it completes,
+// reporting how many shards have been copied each time it is still running.
+func waitForSnapshot(ctx context.Context, backendClient *opensearch.Client, repository string, snapshot string,
+	report func(BackendSnapshotStatus)) (*BackendSnapshotStatus, error) {
+
+	for {
+
+		statusRequest := opensearchapi.SnapshotStatusRequest{
+			Repository: repository,
+			Snapshot:   []string{snapshot},
+		}
+
+		statusResponse, err := statusRequest.Do(ctx, backendClient)
+		if err != nil {
+			return nil, err
+		}
+
+		backendResponse := &BackendSnapshotStatusResponse{}
+		err = decodeBackendResponse(statusResponse.StatusCode, statusResponse.Body, backendResponse)
+		statusResponse.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(backendResponse.Snapshots) == 0 {
+			return nil, errors.New("the backend did not return the status of the snapshot")
+		}
+
+		status := backendResponse.Snapshots[0]
+		switch status.State {
+		case "SUCCESS":
+			return &status, nil
+		case "FAILED", "PARTIAL", "ABORTED":
+			return nil, fmt.Errorf("the snapshot ended in the state '%s', with %d of %d shards failed",
+				status.State, status.ShardsStats.Failed, status.ShardsStats.Total)
+		}
+
+		report(status)
+
+		err = sleepWithContext(ctx, snapshotPollInterval)
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+}
@@ -0,0 +1,84 @@
+package buildonaws
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSnapshotAction(t *testing.T) {
+
+	stub := newBackendStub()
+	stub.repositories["backups"] = map[string]int{}
+	backend := httptest.NewServer(stub)
+	defer backend.Close()
+
+	defaultPollInterval := snapshotPollInterval
+	snapshotPollInterval = time.Millisecond
+	defer func() { snapshotPollInterval = defaultPollInterval }()
+
+	server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+		backendAddressField: tftypes.NewValue(tftypes.String, backend.URL),
+		connectModeField:    tftypes.NewValue(tftypes.String, connectModeSkip),
+	})
+
+	typeName := providerTypeName + snapshotActionTypeName
+
+	progress, diagnostics := invokeTestAction(t, server, schemaResponse, typeName, map[string]tftypes.Value{
+		repositoryField: tftypes.NewValue(tftypes.String, "backups"),
+		snapshotField:   tftypes.NewValue(tftypes.String, "before-v2"),
+	})
+	for _, diagnostic := range diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	// The status is read twice while running, then once it has completed
+	expectedProgress := []string{
+		"Taking the snapshot 'before-v2' of the index 'buildonaws' into the repository 'backups'",
+		"Copied 0 of 2 shards",
+		"Copied 1 of 2 shards",
+		"Took the snapshot 'before-v2' of 2 shards",
+	}
+	if strings.Join(progress, "\n") != strings.Join(expectedProgress, "\n") {
+		t.Errorf("expected the progress %q, got %q", expectedProgress, progress)
+	}
+	if len(stub.snapshottedIndexes) != 1 || stub.snapshottedIndexes[0] != backendIndex {
+		t.Errorf("expected a snapshot of the index '%s', got %v", backendIndex, stub.snapshottedIndexes)
+	}
+
+	testCases := map[string]struct {
+		values map[string]tftypes.Value
+		err    string
+	}{
+		"missing repository": {
+			values: map[string]tftypes.Value{
+				repositoryField: tftypes.NewValue(tftypes.String, "unknown"),
+				snapshotField:   tftypes.NewValue(tftypes.String, "before-v2"),
+			},
+			err: "repository_missing_exception",
+		},
+		"existing snapshot": {
+			values: map[string]tftypes.Value{
+				repositoryField: tftypes.NewValue(tftypes.String, "backups"),
+				snapshotField:   tftypes.NewValue(tftypes.String, "before-v2"),
+				indexField:      tftypes.NewValue(tftypes.String, appearanceIndex),
+			},
+			err: "invalid_snapshot_name_exception",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			_, diagnostics := invokeTestAction(t, server, schemaResponse, typeName, testCase.values)
+			if len(diagnostics) == 0 || !strings.Contains(diagnostics[0].Detail, testCase.err) {
+				t.Errorf("expected an error containing '%s', got %+v", testCase.err, diagnostics)
+			}
+
+		})
+	}
+
+}
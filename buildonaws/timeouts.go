@@ -0,0 +1,28 @@
+package buildonaws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addOperationError reports an error returned by the backend, telling
+// apart the operations that failed because their timeout expired.
+func addOperationError(ctx context.Context, diags *diag.Diagnostics, summary string,
+	operation string, timeout time.Duration, err error) {
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		diags.AddError(
+			"Timeout while running the "+operation+" operation",
+			fmt.Sprintf("The %s operation did not complete within %s. Set a longer '%s' "+
+				"value in the timeouts block to wait longer. Reason: %s", operation, timeout, operation, err.Error()),
+		)
+		return
+	}
+
+	diags.AddError(summary, "Reason: "+err.Error())
+
+}
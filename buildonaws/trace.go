@@ -0,0 +1,211 @@
+package buildonaws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const traceRedacted = "REDACTED"
+
+// traceRedactedHeaders hold credentials, so their values never reach the trace.
+var traceRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// traceRedactedFields hold the identity of characters and the API keys
+// created by the backend, wherever they appear in bodies.
+var traceRedactedFields = []string{identityField, apiKeyField, encodedField}
+
+// traceEntry is one line of the trace file, describing a request
+// sent to the backend along with the response it produced.
+type traceEntry struct {
+	Time            string      `json:"time"`
+	Method          string      `json:"method"`
+	URL             string      `json:"url"`
+	RequestHeaders  http.Header `json:"request_headers,omitempty"`
+	RequestBody     interface{} `json:"request_body,omitempty"`
+	Status          int         `json:"status,omitempty"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    interface{} `json:"response_body,omitempty"`
+	DurationMillis  int64       `json:"duration_ms"`
+	Error           string      `json:"error,omitempty"`
+}
+
+// traceTransport records every request sent to the backend, and the
+// response to it, as a line of NDJSON appended to the trace file. The
+// credentials, API keys and the identity of characters are redacted, so traces
+// can be attached to bug reports. So are the custom headers configured for the
+// provider, as they may carry credentials too.
+type traceTransport struct {
+	transport       http.RoundTripper
+	mutex           sync.Mutex
+	writer          io.Writer
+	redactedHeaders []string
+}
+
+func newTraceTransport(transport http.RoundTripper, writer io.Writer, customHeaders []string) *traceTransport {
+
+	redactedHeaders := append([]string{}, traceRedactedHeaders...)
+	redactedHeaders = append(redactedHeaders, customHeaders...)
+
+	return &traceTransport{transport: transport, writer: writer, redactedHeaders: redactedHeaders}
+
+}
+
+// openTraceFile keeps a single handle on the trace file for the provider,
+// as the same provider may be configured more than once in the process.
+// The handle of a trace file no longer configured is closed, which is
+// also why an empty path is passed when tracing is turned off.
+func (p *buildOnAWSProvider) openTraceFile(traceFile string) (*os.File, error) {
+
+	p.traceMutex.Lock()
+	defer p.traceMutex.Unlock()
+
+	if p.traceFile != nil && p.traceFile.Name() == traceFile {
+		return p.traceFile, nil
+	}
+
+	if p.traceFile != nil {
+		p.traceFile.Close()
+		p.traceFile = nil
+	}
+
+	if traceFile == "" {
+		return nil, nil
+	}
+
+	file, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening the trace file '%s': %w", traceFile, err)
+	}
+
+	p.traceFile = file
+	return file, nil
+
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	entry := &traceEntry{
+		Time:           time.Now().UTC().Format(time.RFC3339Nano),
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestHeaders: redactHeaders(req.Header, t.redactedHeaders),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		bodyContent, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(bodyContent))
+		entry.RequestBody = redactBody(bodyContent)
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	entry.DurationMillis = time.Since(start).Milliseconds()
+
+	if err != nil {
+		entry.Error = err.Error()
+		t.write(entry)
+		return nil, err
+	}
+
+	entry.Status = resp.StatusCode
+	entry.ResponseHeaders = redactHeaders(resp.Header, t.redactedHeaders)
+
+	bodyContent, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(bodyContent))
+	entry.ResponseBody = redactBody(bodyContent)
+	if readErr != nil {
+		entry.Error = readErr.Error()
+	}
+
+	t.write(entry)
+	return resp, readErr
+
+}
+
+// write never fails the request, as tracing is only a debugging aid.
+func (t *traceTransport) write(entry *traceEntry) {
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.writer.Write(append(line, '\n'))
+
+}
+
+func redactHeaders(header http.Header, names []string) http.Header {
+
+	redacted := header.Clone()
+	for _, name := range names {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, traceRedacted)
+		}
+	}
+
+	return redacted
+
+}
+
+// redactBody hides the identity of characters and API keys wherever they
+// appear in the body, such as in documents, search hits or queries. Bodies
+// that are not JSON are kept as strings.
+func redactBody(bodyContent []byte) interface{} {
+
+	if len(bodyContent) == 0 {
+		return nil
+	}
+
+	var body interface{}
+	err := json.Unmarshal(bodyContent, &body)
+	if err != nil {
+		return string(bodyContent)
+	}
+
+	return redactFields(body)
+
+}
+
+func redactFields(value interface{}) interface{} {
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, nestedValue := range typedValue {
+			if isTraceRedactedField(key) {
+				typedValue[key] = traceRedacted
+				continue
+			}
+			typedValue[key] = redactFields(nestedValue)
+		}
+	case []interface{}:
+		for i, nestedValue := range typedValue {
+			typedValue[i] = redactFields(nestedValue)
+		}
+	}
+
+	return value
+
+}
+
+func isTraceRedactedField(key string) bool {
+	for _, field := range traceRedactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package buildonaws
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTraceTransport(t *testing.T) {
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyContent, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bodyContent)
+	}))
+	defer backend.Close()
+
+	traceFile := filepath.Join(t.TempDir(), "trace.ndjson")
+	provider := &buildOnAWSProvider{}
+	traceWriter, err := provider.openTraceFile(traceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.openTraceFile("")
+	client := &http.Client{Transport: newTraceTransport(http.DefaultTransport, traceWriter, []string{"X-Tenant-Token"})}
+
+	// The echoed body stands for both documents and created API keys
+	requestBody := `{"query":{"match":{"identity":"Matt Murdock"}},"api_key":"s3cr3t"}`
+	for i := 0; i < 2; i++ {
+		request, _ := http.NewRequest(http.MethodPost, backend.URL+"/"+backendIndex+"/_search", strings.NewReader(requestBody))
+		request.Header.Set("Authorization", "Basic YWRtaW46YWRtaW4=")
+		request.Header.Set("X-Tenant-Token", "t3n4nt")
+		request.Header.Set("X-Opaque-Id", "trace-test")
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		responseBody, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		// The caller must still receive the original body
+		if string(responseBody) != requestBody {
+			t.Errorf("expected the response body to be left untouched, got '%s'", responseBody)
+		}
+	}
+
+	file, err := os.Open(traceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var entries []*traceEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &traceEntry{}
+		err = json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			t.Fatalf("expected every line to be a JSON object: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries in the trace, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Method != http.MethodPost || entry.Status != http.StatusOK {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.RequestHeaders.Get("Authorization") != traceRedacted {
+		t.Errorf("expected the authorization header to be redacted, got '%s'", entry.RequestHeaders.Get("Authorization"))
+	}
+	if entry.RequestHeaders.Get("X-Tenant-Token") != traceRedacted {
+		t.Errorf("expected the custom header to be redacted, got '%s'", entry.RequestHeaders.Get("X-Tenant-Token"))
+	}
+	if entry.RequestHeaders.Get("X-Opaque-Id") != "trace-test" {
+		t.Errorf("expected other headers to be kept, got '%s'", entry.RequestHeaders.Get("X-Opaque-Id"))
+	}
+
+	for _, body := range []interface{}{entry.RequestBody, entry.ResponseBody} {
+		bodyContent, _ := json.Marshal(body)
+		if strings.Contains(string(bodyContent), "Murdock") || strings.Contains(string(bodyContent), "s3cr3t") ||
+			!strings.Contains(string(bodyContent), traceRedacted) {
+			t.Errorf("expected the identity and API key to be redacted, got '%s'", bodyContent)
+		}
+	}
+
+}
+
+func TestOpenTraceFile(t *testing.T) {
+
+	directory := t.TempDir()
+	provider := &buildOnAWSProvider{}
+
+	firstFile, err := provider.openTraceFile(filepath.Join(directory, "first.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Configuring the provider again keeps the handle already open
+	sameFile, err := provider.openTraceFile(filepath.Join(directory, "first.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sameFile != firstFile {
+		t.Error("expected the trace file to be opened once")
+	}
+
+	secondFile, err := provider.openTraceFile(filepath.Join(directory, "second.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = firstFile.Write([]byte("{}\n")); err == nil {
+		t.Error("expected the trace file no longer configured to be closed")
+	}
+
+	noFile, err := provider.openTraceFile("")
+	if err != nil || noFile != nil {
+		t.Fatalf("expected no trace file when tracing is off, got %v, %v", noFile, err)
+	}
+	if _, err = secondFile.Write([]byte("{}\n")); err == nil {
+		t.Error("expected the trace file to be closed when tracing is turned off")
+	}
+
+}
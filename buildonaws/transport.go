@@ -0,0 +1,427 @@
+package buildonaws
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/time/rate"
+)
+
+// connectionCheckTransport defers the verification of the connectivity
+// and compatibility with the backend until the first request is sent to
+// it. This allows the provider to be configured when the backend is not
+// reachable, such as while validating configurations in offline CI.
+type connectionCheckTransport struct {
+	transport    http.RoundTripper
+	distribution string
+	mutex        sync.Mutex
+	verified     bool
+}
+
+func (t *connectionCheckTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	err := t.verifyConnection(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(req)
+
+}
+
+func (t *connectionCheckTransport) verifyConnection(req *http.Request) error {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.verified {
+		return nil
+	}
+
+	address := (&url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host}).String()
+	header := http.Header{"Authorization": req.Header.Values("Authorization")}
+
+	bodyContent, err := requestBackendInfo(req.Context(), t.transport, address, header)
+	if err != nil {
+		return fmt.Errorf("failure connecting with the backend: %w", err)
+	}
+
+	_, err = checkBackendCompatibility(bodyContent, t.distribution)
+	if err != nil {
+		return fmt.Errorf("unsupported backend: %w", err)
+	}
+
+	t.verified = true
+	return nil
+
+}
+
+// retryTransport resends requests that failed because of transient errors,
+// such as the backend being overloaded or the connection being reset. It
+// waits between attempts using exponential backoff with jitter, unless the
+// backend tells how long to wait with the Retry-After header.
+type retryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	baseWait   time.Duration
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	// The body is read by every attempt, so it must be possible to rewind it
+	if req.Body != nil && req.GetBody == nil {
+		bodyContent, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyContent)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for attempt := 1; ; attempt++ {
+
+		resp, err := t.transport.RoundTrip(req)
+
+		reason := retryReason(req, resp, err)
+		if reason == "" || attempt > t.maxRetries {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Warn(req.Context(), "Retrying request sent to the backend", map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"attempt":     attempt,
+			"max_retries": t.maxRetries,
+			"reason":      reason,
+			"wait":        wait.String(),
+		})
+
+		err = sleepWithContext(req.Context(), wait)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	}
+
+}
+
+// backoff doubles the time to wait after each attempt, picking a random
+// duration in the upper half of it so parallel requests do not retry in
+// lockstep. A wait requested by the backend takes precedence.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return minDuration(wait, t.maxWait)
+		}
+	}
+
+	wait := t.maxWait
+	if shift := attempt - 1; shift < 32 && t.baseWait<<shift < t.maxWait {
+		wait = t.baseWait << shift
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+
+}
+
+// retryReason returns why the request deserves another attempt,
+// or an empty string when the outcome should be returned as is. The
+// backend may have processed the request before the connection dropped
+// or the gateway gave up on it, so those are only retried when sending
+// the request twice does no harm. Rejected requests are always retried.
+func retryReason(req *http.Request, resp *http.Response, err error) string {
+
+	if err != nil {
+		if !idempotentRequest(req) {
+			return ""
+		}
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return err.Error()
+		}
+		return ""
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return resp.Status
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		if idempotentRequest(req) {
+			return resp.Status
+		}
+	}
+
+	return ""
+
+}
+
+// idempotentRequest tells whether the request has the same effect when
+// sent more than once. Documents indexed with POST get an identifier picked
+// by the backend, so each attempt would store another copy, unlike updates
+// and writes naming the document. Searches only read.
+func idempotentRequest(req *http.Request) bool {
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost:
+	default:
+		return false
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if segments[len(segments)-1] == "_search" {
+		return true
+	}
+	if len(segments) >= 3 {
+		switch segments[len(segments)-2] {
+		case "_doc", "_create", "_update":
+			return true
+		}
+	}
+
+	return false
+
+}
+
+// retryAfter parses the Retry-After header, which
+// holds either a number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return maxDuration(time.Until(date), 0), true
+	}
+
+	return 0, false
+
+}
+
+func sleepWithContext(ctx context.Context, wait time.Duration) error {
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// limitTransport caps how many requests are sent to the backend per second
+// and how many of them are in flight at the same time. It is shared by all
+// resources and data sources, so parallel Terraform operations do not trip
+// the rejection thresholds of small clusters. A response counts as in
+// flight until its body is closed, so every caller must close it. The
+// OpenSearch client reads and closes it before returning, but the
+// Elasticsearch client leaves it open for the caller.
+type limitTransport struct {
+	transport http.RoundTripper
+	limiter   *rate.Limiter
+	slots     chan struct{}
+}
+
+// newLimitTransport returns the given transport untouched when
+// there are no limits, as zero means unlimited for both of them.
+func newLimitTransport(transport http.RoundTripper, requestsPerSecond float64, concurrentRequests int) http.RoundTripper {
+
+	if requestsPerSecond <= 0 && concurrentRequests <= 0 {
+		return transport
+	}
+
+	limitTransport := &limitTransport{transport: transport}
+	if requestsPerSecond > 0 {
+		burst := int(math.Ceil(requestsPerSecond))
+		limitTransport.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	if concurrentRequests > 0 {
+		limitTransport.slots = make(chan struct{}, concurrentRequests)
+	}
+
+	return limitTransport
+
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if t.limiter != nil {
+		err := t.limiter.Wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if t.slots == nil {
+		return t.transport.RoundTrip(req)
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	release := func() { <-t.slots }
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+
+}
+
+// releasingBody gives the concurrency slot back once the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// newBaseTransport creates the transport that opens the connections with
+// the backend. Proxies are taken from the environment unless a proxy URL
+// or a list of hosts that must not be proxied is given. Zero pool sizes
+// keep the defaults of the standard library.
+func newBaseTransport(proxyURL string, noProxy string, maxIdleConnections int, maxConnectionsPerHost int) *http.Transport {
+
+	proxyConfig := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		proxyConfig.HTTPProxy = proxyURL
+		proxyConfig.HTTPSProxy = proxyURL
+	}
+	if noProxy != "" {
+		proxyConfig.NoProxy = noProxy
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	// There is a single backend host, so both idle limits are the same
+	if maxIdleConnections > 0 {
+		transport.MaxIdleConns = maxIdleConnections
+		transport.MaxIdleConnsPerHost = maxIdleConnections
+	}
+	if maxConnectionsPerHost > 0 {
+		transport.MaxConnsPerHost = maxConnectionsPerHost
+	}
+
+	return transport
+
+}
+
+// headerTransport adds custom headers, such as the ones used to select a
+// tenant, to every request sent to the backend, including the ones used
+// to verify the connectivity. It can also compress the request bodies.
+type headerTransport struct {
+	transport http.RoundTripper
+	header    http.Header
+	compress  bool
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	req = req.Clone(req.Context())
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+
+	if t.compress && req.Body != nil && req.Body != http.NoBody {
+
+		bodyContent, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var compressedBody bytes.Buffer
+		gzipWriter := gzip.NewWriter(&compressedBody)
+		_, err = gzipWriter.Write(bodyContent)
+		if err == nil {
+			err = gzipWriter.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(compressedBody.Bytes()))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(compressedBody.Bytes())), nil
+		}
+		req.ContentLength = int64(compressedBody.Len())
+		req.Header.Set("Content-Encoding", "gzip")
+
+	}
+
+	return t.transport.RoundTrip(req)
+
+}
@@ -0,0 +1,545 @@
+package buildonaws
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConnectionCheckTransport(t *testing.T) {
+
+	var pings, requests int
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			pings++
+			w.Write([]byte(openSearch2InfoResponse))
+			return
+		}
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &connectionCheckTransport{
+			transport:    http.DefaultTransport,
+			distribution: backendTypeOpenSearch,
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		response, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+	}
+
+	if pings != 1 {
+		t.Errorf("expected the connectivity to be verified once, got %d pings", pings)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests to reach the backend, got %d", requests)
+	}
+
+}
+
+func TestConnectionCheckTransportFailure(t *testing.T) {
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &connectionCheckTransport{
+			transport:    http.DefaultTransport,
+			distribution: backendTypeOpenSearch,
+		},
+	}
+
+	_, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+	if err == nil || !strings.Contains(err.Error(), "failure connecting with the backend") {
+		t.Errorf("expected a connectivity failure, got: %v", err)
+	}
+
+}
+
+func TestConnectionCheckTransportUnsupportedBackend(t *testing.T) {
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(elasticsearch8InfoResponse))
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &connectionCheckTransport{
+			transport:    http.DefaultTransport,
+			distribution: backendTypeOpenSearch,
+		},
+	}
+
+	_, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+	if err == nil || !strings.Contains(err.Error(), "unsupported backend") {
+		t.Errorf("expected an unsupported backend failure, got: %v", err)
+	}
+
+}
+
+func TestRetryTransport(t *testing.T) {
+
+	var requests int
+	var bodies []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		bodyContent, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(bodyContent))
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			transport:  http.DefaultTransport,
+			maxRetries: 3,
+			baseWait:   time.Millisecond,
+			maxWait:    10 * time.Millisecond,
+		},
+	}
+
+	response, err := client.Post(backend.URL+"/"+backendIndex+"/_doc", "application/json", strings.NewReader(`{"fullname":"Daredevil"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		t.Errorf("expected the last attempt to succeed, got '%s'", response.Status)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests to reach the backend, got %d", requests)
+	}
+	for _, body := range bodies {
+		if body != `{"fullname":"Daredevil"}` {
+			t.Errorf("expected the body to be sent with every attempt, got '%s'", body)
+		}
+	}
+
+}
+
+func TestRetryTransportExhausted(t *testing.T) {
+
+	var requests int
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			transport:  http.DefaultTransport,
+			maxRetries: 2,
+			baseWait:   time.Millisecond,
+			maxWait:    10 * time.Millisecond,
+		},
+	}
+
+	response, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the last response to be returned, got '%s'", response.Status)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests to reach the backend, got %d", requests)
+	}
+
+}
+
+func TestRetryTransportPermanentError(t *testing.T) {
+
+	var requests int
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			transport:  http.DefaultTransport,
+			maxRetries: 3,
+			baseWait:   time.Millisecond,
+			maxWait:    10 * time.Millisecond,
+		},
+	}
+
+	response, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if requests != 1 {
+		t.Errorf("expected errors other than transient ones not to be retried, got %d requests", requests)
+	}
+
+}
+
+func TestRetryTransportConnectionReset(t *testing.T) {
+
+	testCases := map[string]struct {
+		method           string
+		path             string
+		expectedRequests int
+	}{
+		"index with a generated identifier": {
+			method:           http.MethodPost,
+			path:             "/" + backendIndex + "/_doc",
+			expectedRequests: 1,
+		},
+		"index with an identifier": {
+			method:           http.MethodPut,
+			path:             "/" + backendIndex + "/_doc/42",
+			expectedRequests: 2,
+		},
+		"update": {
+			method:           http.MethodPost,
+			path:             "/" + backendIndex + "/_update/42",
+			expectedRequests: 2,
+		},
+		"search": {
+			method:           http.MethodPost,
+			path:             "/" + backendIndex + "/_search",
+			expectedRequests: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+
+			// The connection drops after the first request reached the backend
+			var requests int
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					connection, _, _ := w.(http.Hijacker).Hijack()
+					connection.Close()
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer backend.Close()
+
+			client := &http.Client{
+				Transport: &retryTransport{
+					transport:  http.DefaultTransport,
+					maxRetries: 3,
+					baseWait:   time.Millisecond,
+					maxWait:    10 * time.Millisecond,
+				},
+			}
+
+			request, err := http.NewRequest(testCase.method, backend.URL+testCase.path, strings.NewReader(`{"fullname":"Daredevil"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := client.Do(request)
+			if err == nil {
+				response.Body.Close()
+			}
+
+			if testCase.expectedRequests == 1 && err == nil {
+				t.Error("expected the dropped connection to be reported")
+			}
+			if testCase.expectedRequests > 1 && err != nil {
+				t.Errorf("expected the request to be retried, got: %v", err)
+			}
+			if requests != testCase.expectedRequests {
+				t.Errorf("expected %d requests to reach the backend, got %d", testCase.expectedRequests, requests)
+			}
+
+		})
+	}
+
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+
+	transport := &retryTransport{
+		baseWait: 100 * time.Millisecond,
+		maxWait:  time.Second,
+	}
+
+	for attempt, upperBound := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		10: time.Second,
+	} {
+		wait := transport.backoff(attempt, nil)
+		if wait < upperBound/2 || wait > upperBound {
+			t.Errorf("attempt %d: expected a wait between %s and %s, got %s", attempt, upperBound/2, upperBound, wait)
+		}
+	}
+
+	response := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	wait := transport.backoff(1, response)
+	if wait != time.Second {
+		t.Errorf("expected the Retry-After header to be capped by the maximum wait, got %s", wait)
+	}
+
+}
+
+func TestLimitTransportConcurrency(t *testing.T) {
+
+	var mutex sync.Mutex
+	var inFlight, maxInFlight int
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mutex.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: newLimitTransport(http.DefaultTransport, 0, 2),
+	}
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			response, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			response.Body.Close()
+		}()
+	}
+	waitGroup.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+
+}
+
+func TestLimitTransportResourceUpdates(t *testing.T) {
+
+	appearance := map[string]tftypes.Value{
+		idField:              tftypes.NewValue(tftypes.String, "1"),
+		characterIDField:     tftypes.NewValue(tftypes.String, "2"),
+		seriesField:          tftypes.NewValue(tftypes.String, "Daredevil"),
+		issueNumberField:     tftypes.NewValue(tftypes.Number, 1),
+		publicationDateField: tftypes.NewValue(tftypes.String, "1964-04-01"),
+		roleField:            tftypes.NewValue(tftypes.String, appearanceRoles[0]),
+	}
+	characterType := map[string]tftypes.Value{
+		idField:          tftypes.NewValue(tftypes.String, "sidekick"),
+		nameField:        tftypes.NewValue(tftypes.String, "sidekick"),
+		descriptionField: tftypes.NewValue(tftypes.String, "Helps the hero"),
+	}
+	character := map[string]tftypes.Value{
+		idField:       tftypes.NewValue(tftypes.String, "2"),
+		fullNameField: tftypes.NewValue(tftypes.String, "Daredevil"),
+		identityField: tftypes.NewValue(tftypes.String, "Matt Murdock"),
+		typeField:     tftypes.NewValue(tftypes.String, "sidekick"),
+	}
+
+	testCases := map[string]struct {
+		infoResult string
+		resources  map[string]map[string]tftypes.Value
+	}{
+		backendTypeOpenSearch: {
+			infoResult: openSearch2InfoResponse,
+			resources: map[string]map[string]tftypes.Value{
+				appearanceResourceTypeName:    appearance,
+				characterTypeResourceTypeName: characterType,
+				characterResourceTypeName:     character,
+			},
+		},
+		backendTypeElastic: {
+			infoResult: elasticsearch8InfoResponse,
+			resources: map[string]map[string]tftypes.Value{
+				characterResourceTypeName: character,
+			},
+		},
+	}
+
+	for backendType, testCase := range testCases {
+		t.Run(backendType, func(t *testing.T) {
+
+			stub := newBackendStub()
+			stub.infoResult = testCase.infoResult
+			stub.documents["1"] = &ComicCharacter{}
+			stub.documents["2"] = &ComicCharacter{}
+			backend := httptest.NewServer(stub)
+			defer backend.Close()
+
+			// A single slot is taken for good by the first response left open
+			server, schemaResponse := newTestProviderServer(t, map[string]tftypes.Value{
+				backendAddressField:        tftypes.NewValue(tftypes.String, backend.URL),
+				backendTypeField:           tftypes.NewValue(tftypes.String, backendType),
+				connectModeField:           tftypes.NewValue(tftypes.String, connectModeSkip),
+				maxConcurrentRequestsField: tftypes.NewValue(tftypes.Number, 1),
+			})
+
+			done := make(chan []*tfprotov6.Diagnostic)
+			go func() {
+				var diagnostics []*tfprotov6.Diagnostic
+				for i := 0; i < 3; i++ {
+					for resourceTypeName, values := range testCase.resources {
+						diagnostics = append(diagnostics, applyTestResourceChange(t, server, schemaResponse,
+							providerTypeName+resourceTypeName, values, values)...)
+					}
+				}
+				done <- diagnostics
+			}()
+
+			select {
+			case diagnostics := <-done:
+				for _, diagnostic := range diagnostics {
+					t.Errorf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("expected every update to give its concurrency slot back")
+			}
+
+		})
+	}
+
+}
+
+func TestLimitTransportRate(t *testing.T) {
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: newLimitTransport(http.DefaultTransport, 20, 0),
+	}
+
+	// The first 20 requests are a burst, the next 10 wait for tokens
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		response, err := client.Get(backend.URL + "/" + backendIndex + "/_search")
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be throttled, but 30 requests took %s", elapsed)
+	}
+
+}
+
+func TestLimitTransportUnlimited(t *testing.T) {
+
+	if newLimitTransport(http.DefaultTransport, 0, 0) != http.DefaultTransport {
+		t.Error("expected the transport not to be wrapped when there are no limits")
+	}
+
+}
+
+func TestHeaderTransport(t *testing.T) {
+
+	var tenant, encoding, body string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Tenant")
+		encoding = r.Header.Get("Content-Encoding")
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bodyContent, _ := io.ReadAll(gzipReader)
+		body = string(bodyContent)
+	}))
+	defer backend.Close()
+
+	client := &http.Client{
+		Transport: &headerTransport{
+			transport: http.DefaultTransport,
+			header:    http.Header{"X-Tenant": []string{"marvel"}},
+			compress:  true,
+		},
+	}
+
+	response, err := client.Post(backend.URL+"/"+backendIndex+"/_doc", "application/json", strings.NewReader(`{"fullname":"Daredevil"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if tenant != "marvel" {
+		t.Errorf("expected the custom header to be sent, got '%s'", tenant)
+	}
+	if encoding != "gzip" || body != `{"fullname":"Daredevil"}` {
+		t.Errorf("expected the body to be compressed with gzip, got '%s' encoded as '%s'", body, encoding)
+	}
+
+}
+
+func TestBaseTransportProxy(t *testing.T) {
+
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.Write([]byte(openSearch2InfoResponse))
+	}))
+	defer proxy.Close()
+
+	client := &http.Client{
+		Transport: newBaseTransport(proxy.URL, "", 0, 0),
+	}
+
+	response, err := client.Get("http://opensearch.example.com:9200/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if proxiedHost != "opensearch.example.com:9200" {
+		t.Errorf("expected the request to go through the proxy, got host '%s'", proxiedHost)
+	}
+
+	transport := newBaseTransport(proxy.URL, "opensearch.example.com", 0, 0)
+	request, _ := http.NewRequest(http.MethodGet, "http://opensearch.example.com:9200/", nil)
+	proxyURL, err := transport.Proxy(request)
+	if err != nil || proxyURL != nil {
+		t.Errorf("expected hosts listed in no_proxy to be reached directly, got proxy '%v', error: %v", proxyURL, err)
+	}
+
+}
@@ -1,9 +1,34 @@
 package buildonaws
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type BuildOnAWSProviderModel struct {
-	BackendAddress types.String `tfsdk:"backend_address"`
+	BackendAddress        types.String            `tfsdk:"backend_address"`
+	BackendType           types.String            `tfsdk:"backend_type"`
+	ConnectMode           types.String            `tfsdk:"connect_mode"`
+	FilePath              types.String            `tfsdk:"file_path"`
+	Refresh               types.String            `tfsdk:"refresh"`
+	MaxRetries            types.Int64             `tfsdk:"max_retries"`
+	RetryMaxWait          types.String            `tfsdk:"retry_max_wait"`
+	MaxRequestsPerSecond  types.Float64           `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64             `tfsdk:"max_concurrent_requests"`
+	ProxyURL              types.String            `tfsdk:"proxy_url"`
+	NoProxy               types.String            `tfsdk:"no_proxy"`
+	Headers               map[string]types.String `tfsdk:"headers"`
+	CompressRequests      types.Bool              `tfsdk:"compress_requests"`
+	MaxIdleConnections    types.Int64             `tfsdk:"max_idle_connections"`
+	MaxConnectionsPerHost types.Int64             `tfsdk:"max_connections_per_host"`
+	TraceFile             types.String            `tfsdk:"trace_file"`
+	IdentityEncryptionKey types.String            `tfsdk:"identity_encryption_key"`
+	FullNamePattern       types.String            `tfsdk:"fullname_pattern"`
+	MaxTextLength         types.Int64             `tfsdk:"max_text_length"`
+	UniqueFullNames       types.Bool              `tfsdk:"unique_fullnames"`
+	APIKey                types.String            `tfsdk:"api_key"`
 }
 
 type CharacterDataSourceModel struct {
@@ -12,23 +37,54 @@ type CharacterDataSourceModel struct {
 	Identity types.String `tfsdk:"identity"`
 	KnownAs  types.String `tfsdk:"knownas"`
 	Type     types.String `tfsdk:"type"`
+	Universe types.String `tfsdk:"universe"`
+	Tags     types.Set    `tfsdk:"tags"`
 }
 
 type CharacterResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	FullName    types.String `tfsdk:"fullname"`
-	Identity    types.String `tfsdk:"identity"`
-	KnownAs     types.String `tfsdk:"knownas"`
-	Type        types.String `tfsdk:"type"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID          types.String   `tfsdk:"id"`
+	FullName    types.String   `tfsdk:"fullname"`
+	Identity    types.String   `tfsdk:"identity"`
+	KnownAs     types.String   `tfsdk:"knownas"`
+	Type        types.String   `tfsdk:"type"`
+	Universe    types.String   `tfsdk:"universe"`
+	Tags        types.Set      `tfsdk:"tags"`
+	CreatedAt   types.String   `tfsdk:"created_at"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+type CharacterIdentityModel struct {
+	Cluster    types.String `tfsdk:"cluster"`
+	Index      types.String `tfsdk:"index"`
+	DocumentID types.String `tfsdk:"document_id"`
+}
+
+type CharacterListConfigModel struct {
+	FullName types.String `tfsdk:"fullname"`
+	Type     types.String `tfsdk:"type"`
+	Universe types.String `tfsdk:"universe"`
+}
+
+type CharacterStatsDataSourceModel struct {
+	ID         types.String           `tfsdk:"id"`
+	Total      types.Int64            `tfsdk:"total"`
+	ByType     map[string]types.Int64 `tfsdk:"by_type"`
+	ByTag      map[string]types.Int64 `tfsdk:"by_tag"`
+	ByUniverse map[string]types.Int64 `tfsdk:"by_universe"`
 }
 
 type ComicCharacter struct {
-	ID       string `json:"_id,omitempty"`
-	FullName string `json:"fullname,omitempty"`
-	Identity string `json:"identity,omitempty"`
-	KnownAs  string `json:"knownas,omitempty"`
-	Type     string `json:"type,omitempty"`
+	ID                string   `json:"_id,omitempty"`
+	FullName          string   `json:"fullname,omitempty"`
+	Identity          string   `json:"identity,omitempty"`
+	IdentityEncrypted string   `json:"identity_encrypted,omitempty"`
+	KnownAs           string   `json:"knownas"`
+	Type              string   `json:"type,omitempty"`
+	Universe          string   `json:"universe"`
+	Tags              []string `json:"tags"`
+	CreatedAt         string   `json:"created_at,omitempty"`
+	UpdatedAt         string   `json:"updated_at,omitempty"`
 }
 
 type BackendResponse struct {
@@ -37,7 +93,8 @@ type BackendResponse struct {
 }
 
 type BackendSearchResponse struct {
-	Hits struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
 		Total struct {
 			Value int64 `json:"value"`
 		} `json:"total"`
@@ -47,3 +104,262 @@ type BackendSearchResponse struct {
 		} `json:"hits"`
 	} `json:"hits"`
 }
+
+type AppearanceResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	CharacterID     types.String `tfsdk:"character_id"`
+	Series          types.String `tfsdk:"series"`
+	IssueNumber     types.Int64  `tfsdk:"issue_number"`
+	PublicationDate types.String `tfsdk:"publication_date"`
+	Role            types.String `tfsdk:"role"`
+}
+
+type CharacterAppearancesDataSourceModel struct {
+	ID          types.String                    `tfsdk:"id"`
+	CharacterID types.String                    `tfsdk:"character_id"`
+	Appearances []CharacterAppearanceCountModel `tfsdk:"appearances"`
+}
+
+type CharacterAppearanceCountModel struct {
+	CharacterID types.String           `tfsdk:"character_id"`
+	Count       types.Int64            `tfsdk:"count"`
+	Roles       map[string]types.Int64 `tfsdk:"roles"`
+}
+
+type ComicAppearance struct {
+	CharacterID     string `json:"character_id,omitempty"`
+	Series          string `json:"series,omitempty"`
+	IssueNumber     int64  `json:"issue_number"`
+	PublicationDate string `json:"publication_date,omitempty"`
+	Role            string `json:"role,omitempty"`
+}
+
+type BackendAppearanceResponse struct {
+	ID     string           `json:"_id"`
+	Source *ComicAppearance `json:"_source"`
+}
+
+type BackendTermsBucket struct {
+	Key      string `json:"key"`
+	DocCount int64  `json:"doc_count"`
+}
+
+type BackendAppearancesAggregationResponse struct {
+	Aggregations struct {
+		Characters struct {
+			Buckets []*struct {
+				BackendTermsBucket
+				Roles struct {
+					Buckets []*BackendTermsBucket `json:"buckets"`
+				} `json:"roles"`
+			} `json:"buckets"`
+		} `json:"characters"`
+	} `json:"aggregations"`
+}
+
+type CharacterTypeResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+type ComicCharacterType struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type BackendCharacterTypeResponse struct {
+	ID     string              `json:"_id"`
+	Source *ComicCharacterType `json:"_source"`
+}
+
+type IndexMigrationResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	TargetVersion types.Int64  `tfsdk:"target_version"`
+	LatestVersion types.Int64  `tfsdk:"latest_version"`
+}
+
+type IndexMigrationDocument struct {
+	Version   int64  `json:"version"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type BackendIndexMigrationResponse struct {
+	ID     string                  `json:"_id"`
+	Source *IndexMigrationDocument `json:"_source"`
+}
+
+type BackendUpdateByQueryResponse struct {
+	Total    int64             `json:"total"`
+	Updated  int64             `json:"updated"`
+	Failures []json.RawMessage `json:"failures"`
+}
+
+type BackendCharacterStatsResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+	} `json:"hits"`
+	Aggregations struct {
+		Types struct {
+			Buckets []*BackendTermsBucket `json:"buckets"`
+		} `json:"types"`
+		Tags struct {
+			Buckets []*BackendTermsBucket `json:"buckets"`
+		} `json:"tags"`
+		Universes struct {
+			Buckets []*BackendTermsBucket `json:"buckets"`
+		} `json:"universes"`
+	} `json:"aggregations"`
+}
+
+type SearchDataSourceModel struct {
+	ID       types.String     `tfsdk:"id"`
+	Query    types.String     `tfsdk:"query"`
+	Index    types.String     `tfsdk:"index"`
+	Size     types.Int64      `tfsdk:"size"`
+	Sort     []types.String   `tfsdk:"sort"`
+	Total    types.Int64      `tfsdk:"total"`
+	Hits     []SearchHitModel `tfsdk:"hits"`
+	HitsJSON types.String     `tfsdk:"hits_json"`
+}
+
+type SearchHitModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Index      types.String            `tfsdk:"index"`
+	Score      types.Float64           `tfsdk:"score"`
+	Source     map[string]types.String `tfsdk:"source"`
+	SourceJSON types.String            `tfsdk:"source_json"`
+}
+
+type BackendRawSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []*struct {
+			ID     string                     `json:"_id"`
+			Index  string                     `json:"_index"`
+			Score  *float64                   `json:"_score"`
+			Source map[string]json.RawMessage `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+type BackendErrorResponse struct {
+	Error struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+	Status int `json:"status"`
+}
+
+type ClusterDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ClusterName   types.String `tfsdk:"cluster_name"`
+	Distribution  types.String `tfsdk:"distribution"`
+	Version       types.String `tfsdk:"version"`
+	Status        types.String `tfsdk:"status"`
+	NumberOfNodes types.Int64  `tfsdk:"number_of_nodes"`
+	IndexExists   types.Bool   `tfsdk:"index_exists"`
+}
+
+type BackendInfoResponse struct {
+	ClusterName string `json:"cluster_name"`
+	ClusterUUID string `json:"cluster_uuid"`
+	Version     struct {
+		Distribution string `json:"distribution"`
+		Number       string `json:"number"`
+	} `json:"version"`
+}
+
+type BackendClusterHealthResponse struct {
+	Status        string `json:"status"`
+	NumberOfNodes int64  `json:"number_of_nodes"`
+}
+
+type APIKeyEphemeralResourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	Expiration types.String `tfsdk:"expiration"`
+	ID         types.String `tfsdk:"id"`
+	APIKey     types.String `tfsdk:"api_key"`
+	Encoded    types.String `tfsdk:"encoded"`
+	ExpiresAt  types.String `tfsdk:"expires_at"`
+}
+
+type BackendAPIKeyResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Expiration int64  `json:"expiration"`
+	APIKey     string `json:"api_key"`
+	Encoded    string `json:"encoded"`
+}
+
+type BackendInvalidateAPIKeyResponse struct {
+	InvalidatedAPIKeys []string `json:"invalidated_api_keys"`
+	ErrorCount         int64    `json:"error_count"`
+}
+
+type RefreshIndexActionModel struct {
+	Index types.String `tfsdk:"index"`
+}
+
+type BackendRefreshResponse struct {
+	Shards struct {
+		Total      int64 `json:"total"`
+		Successful int64 `json:"successful"`
+		Failed     int64 `json:"failed"`
+	} `json:"_shards"`
+}
+
+type ReindexActionModel struct {
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	Mappings    types.String `tfsdk:"mappings"`
+	Alias       types.String `tfsdk:"alias"`
+}
+
+type BackendReindexTaskResponse struct {
+	Task string `json:"task"`
+}
+
+type BackendReindexStatus struct {
+	Total   int64 `json:"total"`
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+type BackendReindexTaskStatusResponse struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		Status BackendReindexStatus `json:"status"`
+	} `json:"task"`
+	Response *struct {
+		BackendReindexStatus
+		Failures []json.RawMessage `json:"failures"`
+	} `json:"response"`
+	Error *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+type SnapshotActionModel struct {
+	Repository types.String `tfsdk:"repository"`
+	Snapshot   types.String `tfsdk:"snapshot"`
+	Index      types.String `tfsdk:"index"`
+}
+
+type BackendSnapshotStatus struct {
+	State       string `json:"state"`
+	ShardsStats struct {
+		Done   int64 `json:"done"`
+		Failed int64 `json:"failed"`
+		Total  int64 `json:"total"`
+	} `json:"shards_stats"`
+}
+
+type BackendSnapshotStatusResponse struct {
+	Snapshots []BackendSnapshotStatus `json:"snapshots"`
+}
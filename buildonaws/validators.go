@@ -0,0 +1,183 @@
+package buildonaws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.String         = jsonObjectValidator{}
+	_ validator.String         = durationValidator{}
+	_ validator.String         = regexValidator{}
+	_ validator.String         = notBlankValidator{}
+	_ resource.ConfigValidator = distinctValuesValidator{}
+)
+
+// jsonObjectValidator checks that a string attribute holds
+// a valid JSON object, such as the body of a search request.
+type jsonObjectValidator struct{}
+
+func (v jsonObjectValidator) Description(_ context.Context) string {
+	return "value must be a valid JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var jsonObject map[string]interface{}
+	err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &jsonObject)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON object",
+			"The value must be a valid JSON object. Reason: "+err.Error(),
+		)
+	}
+
+}
+
+// durationValidator checks that a string attribute holds a duration
+// of at least one second, such as '30s' or '2m'. Shorter ones are
+// rejected, as API key expirations are sent in whole seconds and
+// a retry wait of zero would not wait at all.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a duration of at least one second, such as '30s' or '2m'"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && duration < time.Second {
+		err = errors.New("the duration is shorter than one second")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			"The value must be a duration of at least one second, such as '30s' or '2m'. Reason: "+err.Error(),
+		)
+	}
+
+}
+
+// regexValidator checks that a string attribute holds
+// a regular expression using the RE2 syntax.
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := regexp.Compile(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid regular expression",
+			"The value must be a valid regular expression. Reason: "+err.Error(),
+		)
+	}
+
+}
+
+// notBlankValidator checks that a string attribute holds
+// something other than whitespace.
+type notBlankValidator struct{}
+
+func (v notBlankValidator) Description(_ context.Context) string {
+	return "value must not be made of whitespace only"
+}
+
+func (v notBlankValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v notBlankValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if strings.TrimSpace(req.ConfigValue.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Blank value",
+			"The value must not be empty or made of whitespace only.",
+		)
+	}
+
+}
+
+// distinctValuesValidator checks that two string attributes of a resource
+// hold different values, ignoring case and surrounding whitespace. The
+// diagnostic is reported on the second attribute.
+type distinctValuesValidator struct {
+	first  path.Path
+	second path.Path
+}
+
+func (v distinctValuesValidator) Description(_ context.Context) string {
+	return "attributes " + v.first.String() + " and " + v.second.String() + " must hold different values"
+}
+
+func (v distinctValuesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v distinctValuesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var first, second types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.first, &first)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.second, &second)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if first.IsNull() || first.IsUnknown() || second.IsNull() || second.IsUnknown() {
+		return
+	}
+
+	if strings.EqualFold(strings.TrimSpace(first.ValueString()), strings.TrimSpace(second.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			v.second,
+			"Identical attribute values",
+			"The attributes "+v.first.String()+" and "+v.second.String()+" must hold different values.",
+		)
+	}
+
+}
@@ -0,0 +1,41 @@
+package buildonaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+
+	testCases := map[string]bool{
+		"1s":     true,
+		"30s":    true,
+		"2m":     true,
+		"1.5s":   true,
+		"0s":     false,
+		"500ms":  false,
+		"-1m":    false,
+		"a week": false,
+	}
+
+	for value, valid := range testCases {
+		t.Run(value, func(t *testing.T) {
+
+			resp := &validator.StringResponse{}
+			durationValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root(retryMaxWaitField),
+				ConfigValue: types.StringValue(value),
+			}, resp)
+
+			if resp.Diagnostics.HasError() == valid {
+				t.Errorf("expected '%s' to be valid: %t, got %v", value, valid, resp.Diagnostics)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,19 @@
+action "buildonaws_refresh_index" "characters" {
+  config {
+    index = "buildonaws"
+  }
+}
+
+resource "buildonaws_character" "deadpool" {
+  fullname = "Deadpool"
+  identity = "Wade Wilson"
+  knownas  = "Merc with a Mouth"
+  type     = "anti-hero"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.buildonaws_refresh_index.characters]
+    }
+  }
+}
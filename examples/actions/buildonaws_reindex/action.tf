@@ -0,0 +1,13 @@
+# Invoked on demand with: terraform apply -invoke=action.buildonaws_reindex.v2
+action "buildonaws_reindex" "v2" {
+  config {
+    destination = "buildonaws_v2"
+    alias       = "characters"
+    mappings = jsonencode({
+      properties = {
+        fullname = { type = "keyword" }
+        universe = { type = "keyword" }
+      }
+    })
+  }
+}
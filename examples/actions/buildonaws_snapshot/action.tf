@@ -0,0 +1,7 @@
+# Invoked on demand with: terraform apply -invoke=action.buildonaws_snapshot.before_v2
+action "buildonaws_snapshot" "before_v2" {
+  config {
+    repository = "backups"
+    snapshot   = "characters-before-v2"
+  }
+}
@@ -0,0 +1,3 @@
+data "buildonaws_character_appearances" "daredevil" {
+  character_id = buildonaws_character.daredevil.id
+}
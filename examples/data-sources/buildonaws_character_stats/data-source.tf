@@ -0,0 +1,2 @@
+data "buildonaws_character_stats" "catalog" {
+}
@@ -0,0 +1,6 @@
+data "buildonaws_cluster" "backend" {
+}
+
+output "backend_is_healthy" {
+  value = data.buildonaws_cluster.backend.status != "red"
+}
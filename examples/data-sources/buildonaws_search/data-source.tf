@@ -0,0 +1,11 @@
+data "buildonaws_search" "villains" {
+  query = jsonencode({
+    query = {
+      match = {
+        type = "villain"
+      }
+    }
+  })
+  size = 10
+  sort = ["fullname.keyword:asc"]
+}
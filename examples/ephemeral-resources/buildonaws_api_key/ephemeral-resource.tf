@@ -0,0 +1,10 @@
+ephemeral "buildonaws_api_key" "ci" {
+  name       = "terraform-ci"
+  expiration = "30m"
+}
+
+provider "buildonaws" {
+  alias        = "ci"
+  backend_type = "elasticsearch"
+  api_key      = ephemeral.buildonaws_api_key.ci.encoded
+}
@@ -0,0 +1,3 @@
+output "daredevil_slug" {
+  value = provider::buildonaws::character_slug(buildonaws_character.daredevil.fullname)
+}
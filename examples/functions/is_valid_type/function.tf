@@ -0,0 +1,8 @@
+variable "character_type" {
+  type = string
+
+  validation {
+    condition     = provider::buildonaws::is_valid_type(var.character_type)
+    error_message = "The character type must be one of the built-in types."
+  }
+}
@@ -0,0 +1,8 @@
+locals {
+  imported = provider::buildonaws::parse_import_id("buildonaws/g3UvQIoBdJ6Qq1Gm2Zkh")
+}
+
+import {
+  to = buildonaws_character.daredevil
+  id = local.imported.document_id
+}
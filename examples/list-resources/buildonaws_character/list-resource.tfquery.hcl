@@ -0,0 +1,9 @@
+list "buildonaws_character" "spider_people" {
+  provider         = buildonaws
+  include_resource = true
+
+  config {
+    fullname = "Spider-Man"
+    type     = "hero"
+  }
+}
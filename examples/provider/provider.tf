@@ -1,3 +1,23 @@
 provider "buildonaws" {
   // backend_address = "http://localhost:9200"
+  // backend_type = "opensearch"
+  // connect_mode = "eager"
+  // file_path = "./characters"
+  // refresh = "wait_for"
+  // max_retries = 3
+  // retry_max_wait = "30s"
+  // max_requests_per_second = 10
+  // max_concurrent_requests = 5
+  // proxy_url = "http://proxy.example.com:3128"
+  // no_proxy = "localhost,.internal"
+  // headers = { "X-Tenant" = "marvel" }
+  // compress_requests = false
+  // max_idle_connections = 100
+  // max_connections_per_host = 0
+  // trace_file = "./buildonaws-trace.ndjson"
+  // identity_encryption_key = var.identity_encryption_key
+  // fullname_pattern = "^[A-Z]"
+  // unique_fullnames = true
+  // max_text_length = 256
+  // api_key = ephemeral.buildonaws_api_key.ci.encoded
 }
@@ -0,0 +1,7 @@
+resource "buildonaws_appearance" "daredevil_1" {
+  character_id = buildonaws_character.daredevil.id
+  series = "Daredevil"
+  issue_number = 1
+  publication_date = "1964-04-01"
+  role = "lead"
+}
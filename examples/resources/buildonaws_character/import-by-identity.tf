@@ -0,0 +1,7 @@
+import {
+  to = buildonaws_character.daredevil
+  identity = {
+    cluster     = "opensearch-cluster"
+    document_id = "g3UvQIoBdJ6Qq1Gm2Zkh"
+  }
+}
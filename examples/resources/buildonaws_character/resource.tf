@@ -3,4 +3,6 @@ resource "buildonaws_character" "daredevil" {
   identity = "Matt Murdock"
   knownas = "The man without fear"
   type = "super-hero"
+  universe = "Earth-616"
+  tags = ["defenders", "new-york"]
 }
@@ -0,0 +1,4 @@
+resource "buildonaws_character_type" "sidekick" {
+  name = "sidekick"
+  description = "Fights alongside the hero"
+}
@@ -0,0 +1,2 @@
+resource "buildonaws_index_migration" "characters" {
+}